@@ -92,6 +92,34 @@ func TestInitiationMessage(t *testing.T) {
 			},
 			fail: false,
 		},
+		{
+			name:  "valid sysName and enterprise specific TLV",
+			input: []byte{0, 2, 0, 8, 120, 114, 118, 57, 107, 45, 114, 49, 0xff, 0xfb, 0, 5, 55, 46, 51, 46, 50},
+			expect: &bmp.InitiationMessage{
+				TLV: []bmp.InformationalTLV{
+					{
+						InformationType:   2,
+						InformationLength: 8,
+						Information:       []byte{120, 114, 118, 57, 107, 45, 114, 49},
+					},
+				},
+				VendorTLVs: []bmp.VendorTLV{
+					{
+						// Enterprise specific type 65531
+						InformationType:   65531,
+						InformationLength: 5,
+						Information:       []byte{55, 46, 51, 46, 50},
+					},
+				},
+			},
+			fail: false,
+		},
+		{
+			name:   "invalid truncated TLV header",
+			input:  []byte{0, 2, 0, 8, 120, 114, 118, 57, 107, 45, 114, 49, 0, 1},
+			expect: nil,
+			fail:   true,
+		},
 		{
 			name:   "invalid 2 TLVs wrong type 3",
 			input:  []byte{0, 3, 0, 10, 32, 55, 46, 50, 46, 49, 46, 50, 51, 73, 0, 2, 0, 8, 120, 114, 118, 57, 107, 45, 114, 49},
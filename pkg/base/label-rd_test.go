@@ -58,6 +58,46 @@ func TestMakeLabel(t *testing.T) {
 	}
 }
 
+func TestLabelIsExplicitNull(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect bool
+	}{
+		{
+			name:   "ipv4 explicit null",
+			input:  []byte{0x00, 0x00, 0x01},
+			expect: true,
+		},
+		{
+			name:   "ipv6 explicit null",
+			input:  []byte{0x00, 0x00, 0x21},
+			expect: true,
+		},
+		{
+			name:   "implicit null",
+			input:  []byte{0x00, 0x00, 0x31},
+			expect: false,
+		},
+		{
+			name:   "real label",
+			input:  []byte{5, 220, 33},
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := MakeLabel(tt.input)
+			if err != nil {
+				t.Fatalf("expected to succeed but failed with error: %+v", err)
+			}
+			if got := l.IsExplicitNull(); got != tt.expect {
+				t.Fatalf("expected %t, got %t for label %d", tt.expect, got, l.Value)
+			}
+		})
+	}
+}
+
 func TestMakeRD(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -14,6 +14,19 @@ type Label struct {
 	BoS   bool  // 1 bit
 }
 
+const (
+	// IPv4ExplicitNullLabel defines IPv4 Explicit NULL label, RFC 3032
+	IPv4ExplicitNullLabel = 0
+	// IPv6ExplicitNullLabel defines IPv6 Explicit NULL label, RFC 3032
+	IPv6ExplicitNullLabel = 2
+)
+
+// IsExplicitNull returns true if the label is either IPv4 or IPv6 Explicit NULL label, a route advertised
+// with Explicit NULL label must not be treated as a route with a real label to swap to.
+func (l *Label) IsExplicitNull() bool {
+	return l.Value == IPv4ExplicitNullLabel || l.Value == IPv6ExplicitNullLabel
+}
+
 // String returns a string representation of the label information
 func (l *Label) String() string {
 	return fmt.Sprintf("Label: %d Exp: %02x BoS: %t", l.Value, l.Exp, l.BoS)
@@ -114,6 +114,57 @@ func (l *LinkNLRI) GetRemoteIGPRouterID() string {
 	return l.RemoteNode.GetIGPRouterID()
 }
 
+// Key returns a key identifying the link regardless of the direction it is advertised in, both
+// advertisements of a bidirectional link get the same key. The key is built from the keys of the link's
+// end points, sorted, where each end point is identified by the node's key and the interface address,
+// or for unnumbered links by the link identifier. Multi-Topology ID is added when present.
+func (l *LinkNLRI) Key() string {
+	local := l.endPointKey(l.LocalNode, true)
+	remote := l.endPointKey(l.RemoteNode, false)
+	if remote < local {
+		local, remote = remote, local
+	}
+	key := local + "|" + remote
+	if l.Link != nil {
+		if mt := l.Link.GetLinkMTID(); mt != nil {
+			key = fmt.Sprintf("mt-%d:", mt.MTID) + key
+		}
+	}
+
+	return key
+}
+
+// endPointKey returns the key of the link's end point located on node nd, local defines whether it is the local
+// or the remote end of the link.
+func (l *LinkNLRI) endPointKey(nd *NodeDescriptor, local bool) string {
+	n := &NodeNLRI{
+		ProtocolID: l.ProtocolID,
+		Identifier: l.Identifier,
+		LocalNode:  nd,
+	}
+	key := n.Key()
+	if l.Link == nil {
+		return key
+	}
+	var addr net.IP
+	if local {
+		addr = l.GetLinkInterfaceAddr()
+	} else {
+		addr = l.GetLinkNeighborAddr()
+	}
+	if addr != nil {
+		return key + "/" + addr.String()
+	}
+	if ids, err := l.GetLinkID(); err == nil {
+		if local {
+			return fmt.Sprintf("%s/id-%d", key, ids[0])
+		}
+		return fmt.Sprintf("%s/id-%d", key, ids[1])
+	}
+
+	return key
+}
+
 // UnmarshalLinkNLRI builds Link NLRI object
 func UnmarshalLinkNLRI(b []byte) (*LinkNLRI, error) {
 	if glog.V(6) {
@@ -91,3 +91,103 @@ func TestUnmarshalLinkNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestUnnumberedLinkNLRI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expectID []uint32
+	}{
+		{
+			name: "unnumbered isis link",
+			input: []byte{
+				0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x12, 0x02, 0x00, 0x00, 0x04, 0x00, 0x00, 0x13, 0xce, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x91,
+				0x01, 0x01, 0x00, 0x12, 0x02, 0x00, 0x00, 0x04, 0x00, 0x00, 0x13, 0xce, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x93,
+				0x01, 0x02, 0x00, 0x08, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x07,
+			},
+			expectID: []uint32{5, 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalLinkNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("test failed with error: %+v", err)
+			}
+			ids, err := got.GetLinkID()
+			if err != nil {
+				t.Fatalf("failed to get link ids with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expectID, ids) {
+				t.Fatalf("expected local and remote link ids %v, got %v", tt.expectID, ids)
+			}
+			if a := got.GetLinkInterfaceAddr(); a != nil {
+				t.Fatalf("expected no interface address for unnumbered link, got %s", a)
+			}
+			if a := got.GetLinkNeighborAddr(); a != nil {
+				t.Fatalf("expected no neighbor address for unnumbered link, got %s", a)
+			}
+		})
+	}
+}
+
+func TestLinkNLRIKey(t *testing.T) {
+	node91 := []byte{0x02, 0x00, 0x00, 0x04, 0x00, 0x00, 0x13, 0xce, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x91}
+	node93 := []byte{0x02, 0x00, 0x00, 0x04, 0x00, 0x00, 0x13, 0xce, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x93}
+	link := func(local, remote []byte, descriptor ...byte) []byte {
+		b := []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+		b = append(b, 0x01, 0x00, 0x00, byte(len(local)))
+		b = append(b, local...)
+		b = append(b, 0x01, 0x01, 0x00, byte(len(remote)))
+		b = append(b, remote...)
+		return append(b, descriptor...)
+	}
+	tests := []struct {
+		name    string
+		forward []byte
+		reverse []byte
+		other   []byte
+		expect  string
+	}{
+		{
+			name:    "numbered link",
+			forward: link(node91, node93, 0x01, 0x03, 0x00, 0x04, 0x09, 0x00, 0x67, 0x01, 0x01, 0x04, 0x00, 0x04, 0x09, 0x00, 0x67, 0x02),
+			reverse: link(node93, node91, 0x01, 0x03, 0x00, 0x04, 0x09, 0x00, 0x67, 0x02, 0x01, 0x04, 0x00, 0x04, 0x09, 0x00, 0x67, 0x01),
+			other:   link(node91, node93, 0x01, 0x03, 0x00, 0x04, 0x09, 0x00, 0x68, 0x01, 0x01, 0x04, 0x00, 0x04, 0x09, 0x00, 0x68, 0x02),
+			expect:  "2:0:5070:0:0000.0000.0091/9.0.103.1|2:0:5070:0:0000.0000.0093/9.0.103.2",
+		},
+		{
+			name:    "unnumbered link",
+			forward: link(node91, node93, 0x01, 0x02, 0x00, 0x08, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x07),
+			reverse: link(node93, node91, 0x01, 0x02, 0x00, 0x08, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x05),
+			other:   link(node91, node93, 0x01, 0x02, 0x00, 0x08, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x00, 0x08),
+			expect:  "2:0:5070:0:0000.0000.0091/id-5|2:0:5070:0:0000.0000.0093/id-7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forward, err := UnmarshalLinkNLRI(tt.forward)
+			if err != nil {
+				t.Fatalf("failed to unmarshal forward link with error: %+v", err)
+			}
+			reverse, err := UnmarshalLinkNLRI(tt.reverse)
+			if err != nil {
+				t.Fatalf("failed to unmarshal reverse link with error: %+v", err)
+			}
+			other, err := UnmarshalLinkNLRI(tt.other)
+			if err != nil {
+				t.Fatalf("failed to unmarshal other link with error: %+v", err)
+			}
+			if got := forward.Key(); got != tt.expect {
+				t.Fatalf("expected key %q, got %q", tt.expect, got)
+			}
+			if forward.Key() != reverse.Key() {
+				t.Fatalf("expected same key for both directions, got %q and %q", forward.Key(), reverse.Key())
+			}
+			if forward.Key() == other.Key() {
+				t.Fatalf("expected different keys for different links, got %q", other.Key())
+			}
+		})
+	}
+}
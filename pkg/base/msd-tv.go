@@ -1,14 +1,52 @@
 package base
 
 import (
+	"fmt"
+
 	"github.com/golang/glog"
 	"github.com/sbezverk/tools"
 )
 
+// MSD Types as defined in https://www.iana.org/assignments/igp-parameters/igp-parameters.xhtml#igp-msd-types
+const (
+	// MSDBaseMPLSImposition defines Base MPLS Imposition MSD, RFC 8491
+	MSDBaseMPLSImposition = 1
+	// MSDERLD defines Entropy Readable Label Depth MSD, RFC 9088
+	MSDERLD = 2
+	// MSDSRHMaxSL defines SRv6 Maximum Segments Left MSD, RFC 9352
+	MSDSRHMaxSL = 41
+	// MSDSRHMaxEndPop defines SRv6 Maximum End Pop MSD, RFC 9352
+	MSDSRHMaxEndPop = 42
+	// MSDSRHMaxHEncaps defines SRv6 Maximum H.Encaps MSD, RFC 9352
+	MSDSRHMaxHEncaps = 44
+	// MSDSRHMaxEndD defines SRv6 Maximum End D MSD, RFC 9352
+	MSDSRHMaxEndD = 45
+)
+
+var msdTypeNames = map[uint8]string{
+	MSDBaseMPLSImposition: "Base MPLS Imposition",
+	MSDERLD:               "ERLD",
+	MSDSRHMaxSL:           "Maximum Segments Left",
+	MSDSRHMaxEndPop:       "Maximum End Pop",
+	MSDSRHMaxHEncaps:      "Maximum H.Encaps",
+	MSDSRHMaxEndD:         "Maximum End D",
+}
+
 // MSDTV defines MSD Type Value tuple
 type MSDTV struct {
-	Type  uint8 `json:"msd_type"`
-	Value uint8 `json:"msd_value"`
+	Type  uint8  `json:"msd_type"`
+	Value uint8  `json:"msd_value"`
+	Name  string `json:"msd_name,omitempty"`
+}
+
+// IsSRv6 returns true if MSD type is one of SRv6 specific MSD types
+func (tv *MSDTV) IsSRv6() bool {
+	switch tv.Type {
+	case MSDSRHMaxSL, MSDSRHMaxEndPop, MSDSRHMaxHEncaps, MSDSRHMaxEndD:
+		return true
+	}
+
+	return false
 }
 
 // UnmarshalMSDTV builds slice of MSD Type Value tuples
@@ -16,6 +54,9 @@ func UnmarshalMSDTV(b []byte) ([]*MSDTV, error) {
 	if glog.V(6) {
 		glog.Infof("UnmarshalMSDTV Raw: %s", tools.MessageHex(b))
 	}
+	if len(b)%2 != 0 {
+		return nil, fmt.Errorf("invalid length %d of msd type value tuples", len(b))
+	}
 	tvs := make([]*MSDTV, 0)
 	for p := 0; p < len(b); {
 		tv := &MSDTV{}
@@ -23,6 +64,7 @@ func UnmarshalMSDTV(b []byte) ([]*MSDTV, error) {
 		p++
 		tv.Value = b[p]
 		p++
+		tv.Name = msdTypeNames[tv.Type]
 		tvs = append(tvs, tv)
 	}
 
@@ -0,0 +1,61 @@
+package base
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalMSDTV(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []*MSDTV
+		srv6   []bool
+		fail   bool
+	}{
+		{
+			name:  "base mpls imposition and srv6 max segments left",
+			input: []byte{0x01, 0x0a, 0x29, 0x08},
+			expect: []*MSDTV{
+				{Type: MSDBaseMPLSImposition, Value: 10, Name: "Base MPLS Imposition"},
+				{Type: MSDSRHMaxSL, Value: 8, Name: "Maximum Segments Left"},
+			},
+			srv6: []bool{false, true},
+		},
+		{
+			name:  "unassigned msd type",
+			input: []byte{0x80, 0x04},
+			expect: []*MSDTV{
+				{Type: 128, Value: 4},
+			},
+			srv6: []bool{false},
+		},
+		{
+			name:  "odd length",
+			input: []byte{0x29, 0x08, 0x2a},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalMSDTV(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("expected to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("expected to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected msd %+v, got %+v", tt.expect, got)
+			}
+			for i, tv := range got {
+				if tv.IsSRv6() != tt.srv6[i] {
+					t.Fatalf("msd type %d expected srv6 %t", tv.Type, tt.srv6[i])
+				}
+			}
+		})
+	}
+}
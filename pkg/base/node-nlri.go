@@ -3,6 +3,7 @@ package base
 import (
 	"encoding/binary"
 	"fmt"
+	"net"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/tools"
@@ -56,6 +57,82 @@ func (n *NodeNLRI) GetNodeOSPFAreaID() string {
 	return n.LocalNode.GetOSPFAreaID()
 }
 
+// IsPseudonode returns true if Node NLRI describes a pseudonode, IS-IS pseudonode IGP Router ID carries 6 bytes
+// of System ID followed by non-zero PSN byte, OSPF pseudonode IGP Router ID carries 4 bytes of DR's Router ID
+// followed by 4 bytes of DR's interface address for OSPFv2 or of DR's interface ID for OSPFv3, RFC 9552.
+func (n *NodeNLRI) IsPseudonode() bool {
+	if n.LocalNode == nil {
+		return false
+	}
+	tlv, ok := n.LocalNode.SubTLV[515]
+	if !ok {
+		return false
+	}
+	switch len(tlv.Value) {
+	case 7:
+		return tlv.Value[6] != 0
+	case 8:
+		return true
+	}
+
+	return false
+}
+
+// Key returns a key identifying the node, the key is built from Protocol ID, Identifier, AS Number, BGP-LS
+// Identifier, OSPF Area ID and IGP Router ID, or for BGP Protocol ID from BGP Router ID and Member ASN.
+// Pseudonodes get keys different from the keys of their designated routers.
+func (n *NodeNLRI) Key() string {
+	var id uint64
+	if len(n.Identifier) == 8 {
+		id = binary.BigEndian.Uint64(n.Identifier)
+	}
+	key := fmt.Sprintf("%d:%d", n.ProtocolID, id)
+	if n.LocalNode == nil {
+		return key
+	}
+	nd := n.LocalNode
+	key += fmt.Sprintf(":%d:%d", nd.GetASN(), nd.GetLSID())
+	if tlv, ok := nd.SubTLV[514]; ok && len(tlv.Value) == 4 {
+		key += fmt.Sprintf(":area-%d", binary.BigEndian.Uint32(tlv.Value))
+	}
+	if n.ProtocolID == BGP {
+		if rid := nd.GetBGPRouterID(); len(rid) == 4 {
+			key += ":" + net.IP(rid).String()
+		}
+		if asn := nd.GetConfedMemberASN(); asn != 0 {
+			key += fmt.Sprintf(":member-%d", asn)
+		}
+		return key
+	}
+	if tlv, ok := nd.SubTLV[515]; ok {
+		key += ":" + igpRouterIDKey(n.ProtocolID, tlv.Value)
+	}
+
+	return key
+}
+
+// igpRouterIDKey returns IGP Router ID formatted for node's key, pseudonode's PSN or DR's interface
+// follow the router id separated by "-".
+func igpRouterIDKey(proto ProtoID, b []byte) string {
+	switch len(b) {
+	case 4:
+		return net.IP(b).String()
+	case 6, 7:
+		s := fmt.Sprintf("%02x%02x.%02x%02x.%02x%02x", b[0], b[1], b[2], b[3], b[4], b[5])
+		if len(b) == 7 && b[6] != 0 {
+			s += fmt.Sprintf("-%02x", b[6])
+		}
+		return s
+	case 8:
+		if proto == OSPFv3 {
+			return fmt.Sprintf("%s-%d", net.IP(b[:4]).String(), binary.BigEndian.Uint32(b[4:]))
+		}
+		return net.IP(b[:4]).String() + "-" + net.IP(b[4:]).String()
+	}
+
+	return fmt.Sprintf("%x", b)
+}
+
 // UnmarshalNodeNLRI builds Node NLRI object
 func UnmarshalNodeNLRI(b []byte) (*NodeNLRI, error) {
 	if glog.V(6) {
@@ -145,3 +145,48 @@ func TestUnmarshalNodeNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeNLRIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		key        string
+		pseudonode bool
+	}{
+		{
+			name: "isis router node",
+			// IS-IS L2, AS 100000, BGP-LS ID 0, IGP Router ID 0000.0000.0006
+			input:      []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x1a, 0x02, 0x00, 0x00, 0x04, 0x00, 0x01, 0x86, 0xa0, 0x02, 0x01, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x06},
+			key:        "2:0:100000:0:0000.0000.0006",
+			pseudonode: false,
+		},
+		{
+			name: "isis pseudonode",
+			// IS-IS L2, AS 100000, BGP-LS ID 0, IGP Router ID 0000.0000.0006 PSN 2
+			input:      []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x1b, 0x02, 0x00, 0x00, 0x04, 0x00, 0x01, 0x86, 0xa0, 0x02, 0x01, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x00, 0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x06, 0x02},
+			key:        "2:0:100000:0:0000.0000.0006-02",
+			pseudonode: true,
+		},
+		{
+			name: "ospfv2 pseudonode",
+			// OSPFv2, AS 100000, Area 0, IGP Router ID DR 10.0.0.1 interface 10.1.1.1
+			input:      []byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x1c, 0x02, 0x00, 0x00, 0x04, 0x00, 0x01, 0x86, 0xa0, 0x02, 0x02, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x00, 0x08, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x01, 0x01, 0x01},
+			key:        "3:0:100000:0:area-0:10.0.0.1-10.1.1.1",
+			pseudonode: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := UnmarshalNodeNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("test failed with error: %+v", err)
+			}
+			if key := n.Key(); key != tt.key {
+				t.Fatalf("expected key %q, got %q", tt.key, key)
+			}
+			if n.IsPseudonode() != tt.pseudonode {
+				t.Fatalf("expected pseudonode %t, got %t", tt.pseudonode, n.IsPseudonode())
+			}
+		})
+	}
+}
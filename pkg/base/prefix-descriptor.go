@@ -1,6 +1,8 @@
 package base
 
 import (
+	"strconv"
+
 	"github.com/golang/glog"
 	"github.com/sbezverk/tools"
 )
@@ -55,12 +57,58 @@ func (pd *PrefixDescriptor) GetPrefixIPReachability(ipv4 bool) *Route {
 	return nil
 }
 
+// OSPFRouteType defines the value of OSPF Route Type TLV (264) of Prefix Descriptor
+// https://www.rfc-editor.org/rfc/rfc9552#section-5.3.2.3
+type OSPFRouteType uint8
+
+const (
+	OSPFIntraArea OSPFRouteType = 1
+	OSPFInterArea OSPFRouteType = 2
+	OSPFExternal1 OSPFRouteType = 3
+	OSPFExternal2 OSPFRouteType = 4
+	OSPFNSSA1     OSPFRouteType = 5
+	OSPFNSSA2     OSPFRouteType = 6
+)
+
+var ospfRouteTypeNames = map[OSPFRouteType]string{
+	OSPFIntraArea: "intra-area",
+	OSPFInterArea: "inter-area",
+	OSPFExternal1: "external-1",
+	OSPFExternal2: "external-2",
+	OSPFNSSA1:     "nssa-1",
+	OSPFNSSA2:     "nssa-2",
+}
+
+// String returns the name of OSPF route type, for undefined types the numeric value is returned
+func (t OSPFRouteType) String() string {
+	if n, ok := ospfRouteTypeNames[t]; ok {
+		return n
+	}
+
+	return strconv.Itoa(int(t))
+}
+
+// IsExternal returns true for OSPF External and NSSA route types
+func (t OSPFRouteType) IsExternal() bool {
+	return t >= OSPFExternal1 && t <= OSPFNSSA2
+}
+
+// GetOSPFRouteType returns OSPF Route type and true, false is returned if the prefix descriptor
+// does not carry OSPF Route Type TLV.
+func (pd *PrefixDescriptor) GetOSPFRouteType() (OSPFRouteType, bool) {
+	tlv, ok := pd.PrefixTLV[264]
+	if !ok || len(tlv.Value) == 0 {
+		return 0, false
+	}
+
+	return OSPFRouteType(tlv.Value[0]), true
+}
+
 // GetPrefixOSPFRouteType returns  OSPF Route type
 func (pd *PrefixDescriptor) GetPrefixOSPFRouteType() uint8 {
-	if tlv, ok := pd.PrefixTLV[264]; ok {
-		return uint8(tlv.Value[0])
-	}
-	return 0
+	t, _ := pd.GetOSPFRouteType()
+
+	return uint8(t)
 }
 
 // UnmarshalPrefixDescriptor build Prefix Descriptor object
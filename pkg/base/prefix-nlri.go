@@ -94,6 +94,57 @@ func (p *PrefixNLRI) GetLocalASN() uint32 {
 	return p.LocalNode.GetASN()
 }
 
+// Topology defines address family of IGP routing topology a prefix belongs to
+type Topology uint8
+
+const (
+	// TopologyUnknown defines a topology which address family cannot be determined
+	TopologyUnknown Topology = iota
+	// TopologyIPv4 defines IPv4 unicast or multicast topology
+	TopologyIPv4
+	// TopologyIPv6 defines IPv6 unicast or multicast topology
+	TopologyIPv6
+)
+
+// String returns a string representation of the topology
+func (t Topology) String() string {
+	switch t {
+	case TopologyIPv4:
+		return "ipv4"
+	case TopologyIPv6:
+		return "ipv6"
+	}
+
+	return "unknown"
+}
+
+// GetTopology returns address family of IGP topology the prefix belongs to, for IS-IS MT-ID 0 and 3
+// are IPv4 unicast and multicast topologies, MT-ID 2 and 4 are IPv6 unicast and multicast topologies,
+// RFC 5120 section 7.5. Prefix without MT-ID TLV belongs to MT-ID 0. For other protocols, the address
+// family of the prefix is returned.
+func (p *PrefixNLRI) GetTopology() Topology {
+	if p.ProtocolID != ISISL1 && p.ProtocolID != ISISL2 {
+		if p.IsIPv4 {
+			return TopologyIPv4
+		}
+		return TopologyIPv6
+	}
+	var mtid uint16
+	if p.Prefix != nil {
+		if m := p.Prefix.GetPrefixMTID(); m != nil {
+			mtid = m.MTID
+		}
+	}
+	switch mtid {
+	case 0, 3:
+		return TopologyIPv4
+	case 2, 4:
+		return TopologyIPv6
+	}
+
+	return TopologyUnknown
+}
+
 // UnmarshalPrefixNLRI builds Prefix NLRI object
 func UnmarshalPrefixNLRI(b []byte, ipv4 bool) (*PrefixNLRI, error) {
 	if glog.V(6) {
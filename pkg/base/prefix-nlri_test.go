@@ -112,3 +112,113 @@ func TestUnmarshalPrefixNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOSPFRouteType(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect OSPFRouteType
+		found  bool
+	}{
+		{
+			name: "external type 2 prefix",
+			// OSPF Route Type TLV 264 with External 2 type, IP Reachability TLV 265 with 10.0.0.0/8
+			input:  []byte{0x01, 0x08, 0x00, 0x01, 0x04, 0x01, 0x09, 0x00, 0x02, 0x08, 0x0a},
+			expect: OSPFExternal2,
+			found:  true,
+		},
+		{
+			name: "intra area prefix",
+			// OSPF Route Type TLV 264 with Intra-Area type
+			input:  []byte{0x01, 0x08, 0x00, 0x01, 0x01},
+			expect: OSPFIntraArea,
+			found:  true,
+		},
+		{
+			name: "no ospf route type",
+			// IP Reachability TLV 265 with 10.0.0.0/8
+			input: []byte{0x01, 0x09, 0x00, 0x02, 0x08, 0x0a},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd, err := UnmarshalPrefixDescriptor(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal prefix descriptor with error: %+v", err)
+			}
+			got, found := pd.GetOSPFRouteType()
+			if found != tt.found {
+				t.Fatalf("expected found %t, got %t", tt.found, found)
+			}
+			if got != tt.expect {
+				t.Fatalf("expected ospf route type %s, got %s", tt.expect, got)
+			}
+		})
+	}
+	if s := OSPFExternal2.String(); s != "external-2" {
+		t.Fatalf("expected external-2, got %s", s)
+	}
+	if !OSPFExternal2.IsExternal() || OSPFInterArea.IsExternal() {
+		t.Fatal("external route type detection failed")
+	}
+}
+
+func TestPrefixNLRIGetTopology(t *testing.T) {
+	tests := []struct {
+		name   string
+		proto  ProtoID
+		ipv4   bool
+		input  []byte
+		expect Topology
+	}{
+		{
+			name:  "isis mt 0 ipv4 prefix",
+			proto: ISISL2,
+			ipv4:  true,
+			// IP Reachability TLV 265 with 10.0.0.0/8
+			input:  []byte{0x01, 0x09, 0x00, 0x02, 0x08, 0x0a},
+			expect: TopologyIPv4,
+		},
+		{
+			name:  "isis mt 2 ipv6 prefix",
+			proto: ISISL2,
+			// MT-ID TLV 263 with MT 2, IP Reachability TLV 265 with 2001:db8::/32
+			input:  []byte{0x01, 0x07, 0x00, 0x02, 0x00, 0x02, 0x01, 0x09, 0x00, 0x05, 0x20, 0x20, 0x01, 0x0d, 0xb8},
+			expect: TopologyIPv6,
+		},
+		{
+			name:  "isis explicit mt 0 ipv6 prefix",
+			proto: ISISL1,
+			// MT-ID TLV 263 with MT 0, IP Reachability TLV 265 with 2001:db8::/32
+			input:  []byte{0x01, 0x07, 0x00, 0x02, 0x00, 0x00, 0x01, 0x09, 0x00, 0x05, 0x20, 0x20, 0x01, 0x0d, 0xb8},
+			expect: TopologyIPv4,
+		},
+		{
+			name:  "isis private mt",
+			proto: ISISL2,
+			ipv4:  true,
+			// MT-ID TLV 263 with MT 100, IP Reachability TLV 265 with 10.0.0.0/8
+			input:  []byte{0x01, 0x07, 0x00, 0x02, 0x00, 0x64, 0x01, 0x09, 0x00, 0x02, 0x08, 0x0a},
+			expect: TopologyUnknown,
+		},
+		{
+			name:  "ospfv3 mt 0 ipv6 prefix",
+			proto: OSPFv3,
+			// IP Reachability TLV 265 with 2001:db8::/32
+			input:  []byte{0x01, 0x09, 0x00, 0x05, 0x20, 0x20, 0x01, 0x0d, 0xb8},
+			expect: TopologyIPv6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd, err := UnmarshalPrefixDescriptor(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal prefix descriptor with error: %+v", err)
+			}
+			p := &PrefixNLRI{ProtocolID: tt.proto, Prefix: pd, IsIPv4: tt.ipv4}
+			if got := p.GetTopology(); got != tt.expect {
+				t.Fatalf("expected topology %s, got %s", tt.expect, got)
+			}
+		})
+	}
+}
@@ -29,6 +29,47 @@ func UnmarshalRoutes(b []byte, pathID bool) ([]Route, error) {
 	if glog.V(6) {
 		glog.Infof("Routes Raw: %s Path ID flag: %t", tools.MessageHex(b), pathID)
 	}
+	routes, err := unmarshalRoutes(b, pathID)
+	if err != nil {
+		// In some cases, Error could be triggered by use of incorrect value of PathID flag, as Add Path capability
+		// might be advertised and received, but BGP Update would not have PathID set due to some other conditions,
+		// example when bgp speakers are in different AS. In error handle, attempting to Unmarshal again with reversed
+		// value of PathID flag.
+		if r, e := unmarshalRoutes(b, !pathID); e == nil {
+			return r, nil
+		}
+		glog.Errorf("failed to reconstruct routes from slice %s with error: %+v", tools.MessageHex(b), err)
+
+		return nil, err
+	}
+	// Path ID with 3 leading zero bytes followed by the default route prefix length of 0, which carries no
+	// prefix bytes, decodes without error when Path ID is not expected, but yields multiple default routes.
+	// Since the same prefix cannot be announced twice without Path ID, such result is a sign of misframing,
+	// decoding with Path ID is used if it yields unique routes.
+	if !pathID && hasDuplicateRoutes(routes) {
+		if r, e := unmarshalRoutes(b, true); e == nil && !hasDuplicateRoutes(r) {
+			return r, nil
+		}
+	}
+
+	return routes, nil
+}
+
+// hasDuplicateRoutes returns true if the same prefix with the same Path ID is found more than once
+func hasDuplicateRoutes(routes []Route) bool {
+	seen := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		k := fmt.Sprintf("%d/%d/%x", r.PathID, r.Length, r.Prefix)
+		if seen[k] {
+			return true
+		}
+		seen[k] = true
+	}
+
+	return false
+}
+
+func unmarshalRoutes(b []byte, pathID bool) ([]Route, error) {
 	routes := make([]Route, 0)
 	if len(b) == 0 {
 		return nil, nil
@@ -74,15 +115,6 @@ func UnmarshalRoutes(b []byte, pathID bool) ([]Route, error) {
 
 error_handle:
 	if err != nil {
-		// In some cases, Error could be triggered by use of incorrect value of PathID flag, as Add Path capability
-		// might be advertised and received, but BGP Update would not have PathID set due to some other conditions,
-		// example when bgp speakers are in different AS. In error handle, attempting to Unmarshal again with reversed
-		// value of PathID flag.
-		if r, e := UnmarshalRoutes(b, !pathID); e == nil {
-			return r, nil
-		}
-		glog.Errorf("failed to reconstruct routes from slice %s with error: %+v", tools.MessageHex(b), err)
-
 		return nil, err
 	}
 
@@ -136,6 +136,35 @@ func TestUnmarshalBaseNLRI(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "add path default route",
+			input:  []byte{0x00, 0x00, 0x00, 0x01, 0x00},
+			pathID: true,
+			expect: []Route{
+				{
+					PathID: 1,
+					Length: 0,
+					Prefix: []byte{},
+				},
+			},
+		},
+		{
+			name:   "add path default route and ipv6 prefix without path id flag",
+			input:  []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x02, 0x40, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00},
+			pathID: false,
+			expect: []Route{
+				{
+					PathID: 1,
+					Length: 0,
+					Prefix: []byte{},
+				},
+				{
+					PathID: 2,
+					Length: 64,
+					Prefix: []byte{0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
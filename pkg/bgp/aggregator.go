@@ -0,0 +1,67 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// asTrans defines reserved 2 bytes AS used to represent 4 bytes ASes, RFC 6793
+const asTrans = 23456
+
+// Aggregator defines a structure of AGGREGATOR and AS4_AGGREGATOR attributes
+type Aggregator struct {
+	AS uint32
+	ID string
+	// Malformed is set when the attribute carries an oversized, IPv6 looking aggregator ID, the ID
+	// is always 4 bytes, in this case ID is built from the first 4 bytes of the received value.
+	Malformed bool
+}
+
+// UnmarshalAggregator builds Aggregator object, AS is either 2 or 4 bytes long depending
+// on 4 bytes AS capability negotiated between the peers, the AS of AS4_AGGREGATOR is always 4 bytes.
+func UnmarshalAggregator(b []byte) (*Aggregator, error) {
+	agg := &Aggregator{}
+	asl := 0
+	switch len(b) {
+	case 6:
+		asl = 2
+	case 8:
+		asl = 4
+	case 18:
+		asl = 2
+		agg.Malformed = true
+	case 20:
+		asl = 4
+		agg.Malformed = true
+	default:
+		return nil, fmt.Errorf("invalid aggregator length %d", len(b))
+	}
+	if asl == 2 {
+		agg.AS = uint32(binary.BigEndian.Uint16(b[:2]))
+	} else {
+		agg.AS = binary.BigEndian.Uint32(b[:4])
+	}
+	agg.ID = net.IP(b[asl : asl+4]).To4().String()
+
+	return agg, nil
+}
+
+// GetAggregator returns AGGREGATOR attribute object, if AGGREGATOR carries AS_TRANS and AS4_AGGREGATOR
+// is present, the AS is taken from AS4_AGGREGATOR, RFC 6793.
+func (ba *BaseAttributes) GetAggregator() (*Aggregator, error) {
+	if len(ba.Aggregator) == 0 {
+		return nil, ErrAttributeNotFound
+	}
+	agg, err := UnmarshalAggregator(ba.Aggregator)
+	if err != nil {
+		return nil, err
+	}
+	if agg.AS == asTrans && len(ba.AS4Aggregator) != 0 {
+		if agg4, err := UnmarshalAggregator(ba.AS4Aggregator); err == nil {
+			agg.AS = agg4.AS
+		}
+	}
+
+	return agg, nil
+}
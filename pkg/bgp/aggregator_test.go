@@ -0,0 +1,53 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalAggregator(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *Aggregator
+		fail   bool
+	}{
+		{
+			name:   "2 bytes as",
+			input:  []byte{0x65, 0x20, 192, 120, 81, 136},
+			expect: &Aggregator{AS: 25888, ID: "192.120.81.136"},
+		},
+		{
+			name:   "4 bytes as",
+			input:  []byte{0, 0, 101, 32, 192, 120, 81, 136},
+			expect: &Aggregator{AS: 25888, ID: "192.120.81.136"},
+		},
+		{
+			name:   "oversized aggregator id",
+			input:  []byte{0, 0, 101, 32, 192, 120, 81, 136, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			expect: &Aggregator{AS: 25888, ID: "192.120.81.136", Malformed: true},
+		},
+		{
+			name:  "invalid length",
+			input: []byte{0, 0, 101, 32, 192},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalAggregator(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected aggregator %+v does not match actual %+v", tt.expect, got)
+			}
+		})
+	}
+}
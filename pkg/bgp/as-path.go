@@ -0,0 +1,239 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// AS_PATH segment types, RFC 4271 and RFC 5065
+const (
+	AS_SET             = 1
+	AS_SEQUENCE        = 2
+	AS_CONFED_SEQUENCE = 3
+	AS_CONFED_SET      = 4
+)
+
+// ASPathSegment defines a single segment of AS_PATH attribute
+type ASPathSegment struct {
+	Type uint8
+	AS   []uint32
+}
+
+func isASPathSegmentType(t byte) bool {
+	return t >= AS_SET && t <= AS_CONFED_SET
+}
+
+// UnmarshalASPath builds a slice of AS_PATH segments preserving segments' types, 2 or 4 bytes AS
+// encoding is detected the same way as for BaseAttributes' ASPath.
+func UnmarshalASPath(b []byte) ([]ASPathSegment, error) {
+	return UnmarshalASPathWithContext(b, nil)
+}
+
+// UnmarshalASPathWithContext builds a slice of AS_PATH segments preserving segments' types, ASes are
+// decoded with the width negotiated for the session, if ctx is nil, it behaves as UnmarshalASPath.
+func UnmarshalASPathWithContext(b []byte, ctx *SessionContext) ([]ASPathSegment, error) {
+	if len(b) == 0 {
+		return make([]ASPathSegment, 0), nil
+	}
+	if ctx != nil {
+		return unmarshalASPath(b, ctx.AS4)
+	}
+	if len(b) < 2 {
+		return nil, fmt.Errorf("invalid as path length %d", len(b))
+	}
+
+	return unmarshalASPath(b, isASPath4(b))
+}
+
+// unmarshalASPath builds a slice of AS_PATH segments with ASes encoded with 4 bytes if as4 is true,
+// or 2 bytes otherwise, an error is returned if the attribute does not match the encoding.
+func unmarshalASPath(b []byte, as4 bool) ([]ASPathSegment, error) {
+	segments := make([]ASPathSegment, 0)
+	asl := 2
+	if as4 {
+		asl = 4
+	}
+	for p := 0; p < len(b); {
+		if p+2 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal as path segment")
+		}
+		seg := ASPathSegment{
+			Type: b[p],
+		}
+		if !isASPathSegmentType(seg.Type) {
+			return nil, fmt.Errorf("invalid as path segment type %d", seg.Type)
+		}
+		l := int(b[p+1])
+		p += 2
+		if p+l*asl > len(b) {
+			return nil, fmt.Errorf("invalid as path segment length %d", l)
+		}
+		seg.AS = make([]uint32, l)
+		for n := 0; n < l; n++ {
+			if as4 {
+				seg.AS[n] = binary.BigEndian.Uint32(b[p : p+4])
+			} else {
+				seg.AS[n] = uint32(binary.BigEndian.Uint16(b[p : p+2]))
+			}
+			p += asl
+		}
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// sessionASPath builds AS_PATH segments from AS_PATH and AS4_PATH attributes received over the session,
+// when the session does not use 4-octet AS, the path is reconstructed from AS4_PATH, RFC 6793.
+func sessionASPath(asPath, as4Path []byte, ctx *SessionContext) ([]ASPathSegment, error) {
+	segments, err := UnmarshalASPathWithContext(asPath, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil || ctx.AS4 || len(as4Path) == 0 {
+		return segments, nil
+	}
+	as4Segments, err := unmarshalASPath(as4Path, true)
+	if err != nil {
+		// Malformed AS4_PATH is discarded, RFC 6793 section 6
+		return segments, nil
+	}
+
+	return reconstructASPath(segments, as4Segments), nil
+}
+
+// asPathLength returns the number of ASes in the path as counted by RFC 6793 section 4.2.3, AS_SET
+// counts as 1 and confederation segments are not counted.
+func asPathLength(asPath []ASPathSegment) int {
+	n := 0
+	for _, seg := range asPath {
+		switch seg.Type {
+		case AS_SET:
+			n++
+		case AS_SEQUENCE:
+			n += len(seg.AS)
+		}
+	}
+
+	return n
+}
+
+// reconstructASPath builds AS path from 2 bytes AS_PATH and AS4_PATH received over a session
+// without 4-octet AS capability, RFC 6793 section 4.2.3. Confederation segments of AS4_PATH are
+// discarded, if AS_PATH is shorter than AS4_PATH, AS4_PATH is ignored, otherwise the leading
+// segments and ASes of AS_PATH not covered by AS4_PATH are prepended to AS4_PATH.
+func reconstructASPath(asPath, as4Path []ASPathSegment) []ASPathSegment {
+	as4 := make([]ASPathSegment, 0, len(as4Path))
+	for _, seg := range as4Path {
+		if seg.Type == AS_SET || seg.Type == AS_SEQUENCE {
+			as4 = append(as4, seg)
+		}
+	}
+	n := asPathLength(asPath) - asPathLength(as4)
+	if n < 0 {
+		return asPath
+	}
+	path := make([]ASPathSegment, 0, len(asPath)+len(as4))
+	for _, seg := range asPath {
+		if seg.Type == AS_CONFED_SEQUENCE || seg.Type == AS_CONFED_SET {
+			path = append(path, seg)
+			continue
+		}
+		if n == 0 {
+			break
+		}
+		switch seg.Type {
+		case AS_SET:
+			path = append(path, seg)
+			n--
+		case AS_SEQUENCE:
+			k := min(n, len(seg.AS))
+			path = append(path, ASPathSegment{Type: AS_SEQUENCE, AS: seg.AS[:k]})
+			n -= k
+		}
+	}
+
+	return append(path, as4...)
+}
+
+// flattenASPath returns ASes of all segments of the path in order, nil is returned for an empty path
+func flattenASPath(asPath []ASPathSegment) []uint32 {
+	if len(asPath) == 0 {
+		return nil
+	}
+	path := make([]uint32, 0)
+	for _, seg := range asPath {
+		path = append(path, seg.AS...)
+	}
+
+	return path
+}
+
+// NeighborAS returns the AS of directly connected eBGP peer, which is the leftmost AS of the first
+// AS_SEQUENCE segment following any confederation segments, and true. If the path is empty, consists
+// only of confederation segments or the first non confederation segment is AS_SET, false is returned.
+func NeighborAS(asPath []ASPathSegment) (uint32, bool) {
+	for _, seg := range asPath {
+		switch seg.Type {
+		case AS_CONFED_SEQUENCE, AS_CONFED_SET:
+			continue
+		case AS_SEQUENCE:
+			if len(seg.AS) == 0 {
+				continue
+			}
+			return seg.AS[0], true
+		}
+		return 0, false
+	}
+
+	return 0, false
+}
+
+// PrependCount returns for each AS found in AS_SEQUENCE segments of the path the longest number of consecutive
+// times the AS appears, a count greater than 1 means the AS was prepended. Adjacent AS_SEQUENCE segments
+// are treated as a single sequence, any other segment type breaks the sequence and its ASes are not counted.
+func PrependCount(asPath []ASPathSegment) map[uint32]int {
+	counts := make(map[uint32]int)
+	var last uint32
+	run := 0
+	for _, seg := range asPath {
+		if seg.Type != AS_SEQUENCE {
+			run = 0
+			continue
+		}
+		for _, as := range seg.AS {
+			if run != 0 && as == last {
+				run++
+			} else {
+				last = as
+				run = 1
+			}
+			if run > counts[as] {
+				counts[as] = run
+			}
+		}
+	}
+
+	return counts
+}
+
+// GetAttrASPath check for presense of BGP Attribute AS_PATH (2) and instantiates its segments, when BGP Update
+// was decoded with SessionContext, ASes are decoded and reconstructed from AS4_PATH as for BaseAttributes' ASPath.
+func (up *Update) GetAttrASPath() ([]ASPathSegment, error) {
+	var asPath, as4Path []byte
+	found := false
+	for _, attr := range up.PathAttributes {
+		switch attr.AttributeType {
+		case 2:
+			asPath = attr.Attribute
+			found = true
+		case 17:
+			as4Path = attr.Attribute
+		}
+	}
+	if !found {
+		return nil, ErrAttributeNotFound
+	}
+
+	return sessionASPath(asPath, as4Path, up.ctx)
+}
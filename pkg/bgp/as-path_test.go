@@ -0,0 +1,191 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNeighborAS(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		segments []ASPathSegment
+		expect   uint32
+		ok       bool
+	}{
+		{
+			name:  "as4 sequence",
+			input: []byte{0x02, 0x03, 0x00, 0x00, 0x88, 0x38, 0x00, 0x00, 0x9a, 0x6d, 0x00, 0x00, 0x19, 0x35},
+			segments: []ASPathSegment{
+				{Type: AS_SEQUENCE, AS: []uint32{34872, 39533, 6453}},
+			},
+			expect: 34872,
+			ok:     true,
+		},
+		{
+			name:  "as2 sequence followed by set",
+			input: []byte{0x02, 0x02, 0x88, 0x38, 0x9a, 0x6d, 0x01, 0x02, 0x19, 0x35, 0x0a, 0x7f},
+			segments: []ASPathSegment{
+				{Type: AS_SEQUENCE, AS: []uint32{34872, 39533}},
+				{Type: AS_SET, AS: []uint32{6453, 2687}},
+			},
+			expect: 34872,
+			ok:     true,
+		},
+		{
+			name: "confed sequence prefixed",
+			input: []byte{0x03, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xea,
+				0x02, 0x02, 0x00, 0x00, 0x88, 0x38, 0x00, 0x00, 0x9a, 0x6d},
+			segments: []ASPathSegment{
+				{Type: AS_CONFED_SEQUENCE, AS: []uint32{65001, 65002}},
+				{Type: AS_SEQUENCE, AS: []uint32{34872, 39533}},
+			},
+			expect: 34872,
+			ok:     true,
+		},
+		{
+			name:  "confed set prefixed",
+			input: []byte{0x04, 0x01, 0xfd, 0xe9, 0x02, 0x01, 0x88, 0x38},
+			segments: []ASPathSegment{
+				{Type: AS_CONFED_SET, AS: []uint32{65001}},
+				{Type: AS_SEQUENCE, AS: []uint32{34872}},
+			},
+			expect: 34872,
+			ok:     true,
+		},
+		{
+			name:  "confed sequence only",
+			input: []byte{0x03, 0x01, 0x00, 0x00, 0xfd, 0xe9},
+			segments: []ASPathSegment{
+				{Type: AS_CONFED_SEQUENCE, AS: []uint32{65001}},
+			},
+		},
+		{
+			name:     "empty path",
+			input:    []byte{},
+			segments: []ASPathSegment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := UnmarshalASPath(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal as path with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.segments, segments) {
+				t.Logf("Differences: %+v", deep.Equal(tt.segments, segments))
+				t.Fatal("the expected as path segments do not match the actual")
+			}
+			as, ok := NeighborAS(segments)
+			if ok != tt.ok {
+				t.Fatalf("expected %t, got %t", tt.ok, ok)
+			}
+			if as != tt.expect {
+				t.Fatalf("expected neighbor as %d, got %d", tt.expect, as)
+			}
+		})
+	}
+}
+
+func TestPrependCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect map[uint32]int
+	}{
+		{
+			name: "as 65001 prepended three times",
+			input: []byte{0x02, 0x05, 0x00, 0x00, 0xfd, 0xea, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xe9,
+				0x00, 0x00, 0xfd, 0xeb},
+			expect: map[uint32]int{65002: 1, 65001: 3, 65003: 1},
+		},
+		{
+			name: "prepending spanning sequence segments",
+			input: []byte{0x02, 0x02, 0x00, 0x00, 0xfd, 0xea, 0x00, 0x00, 0xfd, 0xe9,
+				0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xe9},
+			expect: map[uint32]int{65002: 1, 65001: 3},
+		},
+		{
+			name: "longest run of non consecutive appearances",
+			input: []byte{0x02, 0x05, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xea, 0x00, 0x00, 0xfd, 0xe9,
+				0x00, 0x00, 0xfd, 0xea},
+			expect: map[uint32]int{65001: 2, 65002: 1},
+		},
+		{
+			name: "as set is not counted",
+			input: []byte{0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xe9,
+				0x01, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xea},
+			expect: map[uint32]int{65001: 2},
+		},
+		{
+			name:   "empty path",
+			input:  []byte{},
+			expect: map[uint32]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := UnmarshalASPath(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			if got := PrependCount(segments); !reflect.DeepEqual(tt.expect, got) {
+				t.Errorf("expected prepend count %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetAttrASPathWithContext(t *testing.T) {
+	// AS_PATH with AS_CONFED_SEQUENCE, AS_SEQUENCE and AS_SET of 2 bytes ASes followed by AS4_PATH
+	input := []byte{
+		0x00, 0x00, 0x00, 0x26,
+		0x40, 0x02, 0x10, 0x03, 0x01, 0xfd, 0xe9, 0x02, 0x02, 0x00, 0x64, 0x5b, 0xa0, 0x01, 0x02, 0x01, 0x2c, 0x5b, 0xa0,
+		0xc0, 0x11, 0x10, 0x02, 0x01, 0x00, 0x04, 0x03, 0xb8, 0x01, 0x02, 0x00, 0x00, 0x01, 0x2c, 0x00, 0x04, 0x03, 0xb9,
+	}
+	tests := []struct {
+		name   string
+		ctx    *SessionContext
+		expect []ASPathSegment
+	}{
+		{
+			name: "4-octet as not negotiated",
+			ctx:  &SessionContext{AS4: false},
+			expect: []ASPathSegment{
+				{Type: AS_CONFED_SEQUENCE, AS: []uint32{65001}},
+				{Type: AS_SEQUENCE, AS: []uint32{100}},
+				{Type: AS_SEQUENCE, AS: []uint32{263096}},
+				{Type: AS_SET, AS: []uint32{300, 263097}},
+			},
+		},
+		{
+			name: "4-octet as not known",
+			expect: []ASPathSegment{
+				{Type: AS_CONFED_SEQUENCE, AS: []uint32{65001}},
+				{Type: AS_SEQUENCE, AS: []uint32{100, 23456}},
+				{Type: AS_SET, AS: []uint32{300, 23456}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdateWithContext(input, tt.ctx)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp update with error: %+v", err)
+			}
+			segments, err := u.GetAttrASPath()
+			if err != nil {
+				t.Fatalf("failed to get as path with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, segments) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, segments))
+				t.Fatal("the expected as path segments do not match the actual")
+			}
+			if !reflect.DeepEqual(flattenASPath(segments), u.BaseAttributes.ASPath) {
+				t.Fatalf("as path segments %+v do not match base attributes as path %+v", segments, u.BaseAttributes.ASPath)
+			}
+		})
+	}
+}
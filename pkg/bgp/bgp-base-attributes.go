@@ -38,7 +38,7 @@ type BaseAttributes struct {
 	// PMSITunnel
 	TunnelEncapAttr []byte `json:"-"`
 	// TraficEng
-	// IPv6SpecExtCommunity
+	IPv6ExtCommunityList []string `json:"ipv6_ext_community_list,omitempty"`
 	// AIGP
 	// PEDistinguisherLable
 	LgCommunityList []string `json:"large_community_list,omitempty"`
@@ -110,6 +110,10 @@ func (ba *BaseAttributes) Equal(oba *BaseAttributes) (bool, []string) {
 		equal = false
 		diffs = append(diffs, "as4_aggregator mismatch")
 	}
+	if !reflect.DeepEqual(sort.SortMergeComparableSlice(ba.IPv6ExtCommunityList), sort.SortMergeComparableSlice(oba.IPv6ExtCommunityList)) {
+		equal = false
+		diffs = append(diffs, "ipv6_ext_community_list mismatch")
+	}
 	if !reflect.DeepEqual(sort.SortMergeComparableSlice(ba.LgCommunityList), sort.SortMergeComparableSlice(oba.LgCommunityList)) {
 		equal = false
 		diffs = append(diffs, "large_community_list mismatch")
@@ -122,10 +126,15 @@ func (ba *BaseAttributes) Equal(oba *BaseAttributes) (bool, []string) {
 // UnmarshalBGPBaseAttributes discovers all present Base Attributes in BGP Update
 // and instantiates BaseAttributes object
 func UnmarshalBGPBaseAttributes(b []byte) (*BaseAttributes, error) {
+	return unmarshalBGPBaseAttributes(b, nil)
+}
+
+func unmarshalBGPBaseAttributes(b []byte, ctx *SessionContext) (*BaseAttributes, error) {
 	if glog.V(6) {
 		glog.Infof("UnmarshalBGPBaseAttributes RAW: %+v", tools.MessageHex(b))
 	}
 	baseAttr := BaseAttributes{}
+	var asPath, as4Path []byte
 	for p := 0; p < len(b); {
 		flag := b[p]
 		p++
@@ -144,7 +153,11 @@ func UnmarshalBGPBaseAttributes(b []byte) (*BaseAttributes, error) {
 		case 1:
 			baseAttr.Origin = unmarshalAttrOrigin(b[p : p+int(l)])
 		case 2:
-			baseAttr.ASPath = unmarshalAttrASPath(b[p : p+int(l)])
+			// With the session known, AS_PATH is decoded together with AS4_PATH once all attributes are found
+			asPath = b[p : p+int(l)]
+			if ctx == nil {
+				baseAttr.ASPath = unmarshalAttrASPath(asPath)
+			}
 			baseAttr.ASPathCount = int32(len(baseAttr.ASPath))
 		case 3:
 			baseAttr.Nexthop = unmarshalAttrNextHop(b[p : p+int(l)])
@@ -165,6 +178,7 @@ func UnmarshalBGPBaseAttributes(b []byte) (*BaseAttributes, error) {
 		case 16:
 			baseAttr.ExtCommunityList = unmarshalAttrExtCommunity(b[p : p+int(l)])
 		case 17:
+			as4Path = b[p : p+int(l)]
 			baseAttr.AS4Path = unmarshalAttrAS4Path(b[p : p+int(l)])
 			baseAttr.AS4PathCount = int32(len(baseAttr.AS4Path))
 		case 18:
@@ -175,6 +189,7 @@ func UnmarshalBGPBaseAttributes(b []byte) (*BaseAttributes, error) {
 			copy(baseAttr.TunnelEncapAttr, b[p:p+int(l)])
 		case 24:
 		case 25:
+			baseAttr.IPv6ExtCommunityList = unmarshalAttrIPv6ExtCommunity(b[p : p+int(l)])
 		case 26:
 		case 27:
 		case 28:
@@ -186,6 +201,14 @@ func UnmarshalBGPBaseAttributes(b []byte) (*BaseAttributes, error) {
 		}
 		p += int(l)
 	}
+	if ctx != nil && asPath != nil {
+		segments, err := sessionASPath(asPath, as4Path, ctx)
+		if err != nil {
+			return nil, &AttributeError{AttributeType: 2, Err: err}
+		}
+		baseAttr.ASPath = flattenASPath(segments)
+		baseAttr.ASPathCount = int32(len(baseAttr.ASPath))
+	}
 	// Calculating hash of all recovered base attributes
 	ba, err := json.Marshal(baseAttr)
 	if err != nil {
@@ -211,7 +234,8 @@ func unmarshalAttrOrigin(b []byte) string {
 	}
 }
 
-// unmarshalAttrASPath returns a slice with a list of ASes
+// unmarshalAttrASPath returns a slice with a list of ASes, 2 or 4 bytes AS encoding is detected
+// from the attribute's content.
 func unmarshalAttrASPath(b []byte) []uint32 {
 	if len(b) == 0 {
 		return nil
@@ -383,6 +407,20 @@ func unmarshalAttrExtCommunity(b []byte) []string {
 	return s
 }
 
+// unmarshalAttrIPv6ExtCommunity returns a slice with all IPv6 Address Specific extended communities found in bgp update
+func unmarshalAttrIPv6ExtCommunity(b []byte) []string {
+	ext, err := UnmarshalBGPIPv6ExtCommunity(b)
+	if err != nil {
+		return nil
+	}
+	s := make([]string, len(ext))
+	for i, c := range ext {
+		s[i] = c.String()
+	}
+
+	return s
+}
+
 // unmarshalAttrLgCommunity returns a slice with all large communities found in bgp update
 func unmarshalAttrLgCommunity(b []byte) []string {
 	lg, err := UnmarshalBGPLgCommunity(b)
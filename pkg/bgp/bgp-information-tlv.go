@@ -1,6 +1,9 @@
 package bgp
 
 import (
+	"encoding/binary"
+	"fmt"
+
 	"github.com/golang/glog"
 	"github.com/sbezverk/tools"
 )
@@ -14,35 +17,61 @@ type InformationalTLV struct {
 
 // UnmarshalBGPTLV builds a slice of Informational TLVs
 func UnmarshalBGPTLV(b []byte) ([]InformationalTLV, Capability, error) {
+	return unmarshalBGPTLV(b, false)
+}
+
+// unmarshalBGPTLV builds a slice of Informational TLVs, extended defines if the length of each optional
+// parameter is encoded in 2 bytes as defined in RFC 9072, for such parameters Length is set only when
+// the length fits 1 byte, Value always carries the complete parameter.
+func unmarshalBGPTLV(b []byte, extended bool) ([]InformationalTLV, Capability, error) {
 	if glog.V(6) {
 		glog.Infof("BGPTLV Raw: %s", tools.MessageHex(b))
 	}
+	hl := 2
+	if extended {
+		hl = 3
+	}
 	tlvs := make([]InformationalTLV, 0)
 	caps := make(Capability)
 	for p := 0; p < len(b); {
+		if p+hl > len(b) {
+			return nil, nil, fmt.Errorf("not enough bytes to unmarshal optional parameter")
+		}
 		t := b[p]
 		p++
-		l := b[p]
-		p++
+		var l int
+		if extended {
+			l = int(binary.BigEndian.Uint16(b[p : p+2]))
+			p += 2
+		} else {
+			l = int(b[p])
+			p++
+		}
+		if extended && p+l > len(b) {
+			return nil, nil, fmt.Errorf("invalid optional parameter %d length %d", t, l)
+		}
 		// Check if informational TLV carries Capabilities
 		if t == 2 {
-			c, err := UnmarshalBGPCapability(b[p : p+int(l)])
+			c, err := UnmarshalBGPCapability(b[p : p+l])
 			if err != nil {
 				return nil, nil, err
 			}
 			copyCapabilitiyMap(c, caps)
-			p += int(l)
+			p += l
 			continue
 		}
 		// Other than Capabilities informational tlvs are stored as is
 		v := make([]byte, l)
-		copy(v, b[p:p+int(l)])
-		tlvs = append(tlvs, InformationalTLV{
-			Type:   t,
-			Length: l,
-			Value:  v,
-		})
-		p += int(l)
+		copy(v, b[p:p+l])
+		tlv := InformationalTLV{
+			Type:  t,
+			Value: v,
+		}
+		if l <= 255 {
+			tlv.Length = byte(l)
+		}
+		tlvs = append(tlvs, tlv)
+		p += l
 	}
 
 	return tlvs, caps, nil
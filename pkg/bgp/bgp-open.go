@@ -11,9 +11,13 @@ import (
 const (
 	// BGPMinOpenMessageLength defines a minimum length of BGP Open Message
 	BGPMinOpenMessageLength = 29
+	// ExtOptParamType defines the value of Non-Ext OP Type and Non-Ext OP Len fields signaling
+	// the use of Extended Optional Parameters Length, RFC 9072
+	ExtOptParamType = 255
 )
 
-// OpenMessage defines BGP Open Message structure
+// OpenMessage defines BGP Open Message structure, ExtOptParamLen is set only when optional parameters
+// are encoded with Extended Optional Parameters Length, RFC 9072
 type OpenMessage struct {
 	Length             int16
 	Type               byte
@@ -22,6 +26,7 @@ type OpenMessage struct {
 	HoldTime           int16
 	BGPID              []byte
 	OptParamLen        byte
+	ExtOptParamLen     uint16
 	OptionalParameters []InformationalTLV
 	Capabilities       Capability
 }
@@ -35,13 +40,23 @@ func (o *OpenMessage) GetCapabilities() Capability {
 // in case of true, it also returns 4 bytes Autonomous System Number.
 func (o *OpenMessage) Is4BytesASCapable() (uint32, bool) {
 	v, ok := o.Capabilities[65]
-	if !ok {
+	if !ok || len(v) == 0 || len(v[0].Value) != 4 {
 		return 0, false
 	}
 
 	return binary.BigEndian.Uint32(v[0].Value), true
 }
 
+// GetAS returns Autonomous System Number of the speaker originated Open message, 4 bytes AS number
+// from Support for 4-octet AS number capability is returned if present, otherwise My Autonomous System.
+func (o *OpenMessage) GetAS() uint32 {
+	if as, ok := o.Is4BytesASCapable(); ok {
+		return as
+	}
+
+	return uint32(o.MyAS)
+}
+
 // IsAddPathCapable returns a map of NLRI types and bool indicating if a particular NLRI type
 // supports Add Path capability
 func (o *OpenMessage) AddPathCapability() map[int]bool {
@@ -89,6 +104,84 @@ func (o *OpenMessage) IsMultiLabelCapable() bool {
 	return false
 }
 
+// IsRouteRefreshCapable returns true if Open message originated by a bgp speaker supporting Route Refresh
+// capability (2), RFC 2918, or its prestandard version (128) still advertised by some implementations.
+func (o *OpenMessage) IsRouteRefreshCapable() bool {
+	if _, ok := o.Capabilities[2]; ok {
+		return true
+	}
+	if _, ok := o.Capabilities[128]; ok {
+		return true
+	}
+
+	return false
+}
+
+// IsEnhancedRouteRefreshCapable returns true if Open message originated by a bgp speaker supporting
+// Enhanced Route Refresh capability (70), RFC 7313, such speaker demarcates re-advertised routes
+// with Beginning and End of Route Refresh messages.
+func (o *OpenMessage) IsEnhancedRouteRefreshCapable() bool {
+	if _, ok := o.Capabilities[70]; ok {
+		return true
+	}
+
+	return false
+}
+
+// GetHostname returns host name and domain name advertised in FQDN capability (73), or its prestandard
+// version (184), and true, if the capability is not present or malformed, false is returned. The capability
+// carries 1 byte of host name length, host name, 1 byte of domain name length and domain name,
+// draft-walton-bgp-hostname-capability.
+func (o *OpenMessage) GetHostname() (string, string, bool) {
+	for _, code := range []uint8{73, 184} {
+		v, ok := o.Capabilities[code]
+		if !ok || len(v) == 0 {
+			continue
+		}
+		b := v[0].Value
+		if len(b) < 1 || 1+int(b[0]) > len(b) {
+			return "", "", false
+		}
+		host := string(b[1 : 1+int(b[0])])
+		p := 1 + int(b[0])
+		if p == len(b) {
+			// Domain name length is missing, some implementations advertise host name only
+			return host, "", true
+		}
+		if p+1+int(b[p]) > len(b) {
+			return "", "", false
+		}
+
+		return host, string(b[p+1 : p+1+int(b[p])]), true
+	}
+
+	return "", "", false
+}
+
+// MultiLabelCapability returns a map of NLRI types and the maximum number of labels the speaker is able
+// to receive with a single NLRI of that type, as advertised in Multiple Labels capability (8), RFC 8277.
+// Each capability entry is 4 bytes of AFI, SAFI and Count.
+func (o *OpenMessage) MultiLabelCapability() map[int]uint8 {
+	m := make(map[int]uint8)
+	v, ok := o.Capabilities[8]
+	if !ok {
+		return m
+	}
+	for _, c := range v {
+		if len(c.Value)%4 != 0 {
+			glog.Errorf("invalid length of Multiple Labels capability %d", len(c.Value))
+			return m
+		}
+		for p := 0; p < len(c.Value); p += 4 {
+			afi := binary.BigEndian.Uint16(c.Value[p : p+2])
+			safi := c.Value[p+2]
+			m[NLRIMessageType(afi, safi)] = c.Value[p+3]
+		}
+	}
+
+	return m
+}
+
 // UnmarshalBGPOpenMessage validate information passed in byte slice and returns BGPOpenMessage object
 func UnmarshalBGPOpenMessage(b []byte) (*OpenMessage, error) {
 	if glog.V(6) {
@@ -122,8 +215,23 @@ func UnmarshalBGPOpenMessage(b []byte) (*OpenMessage, error) {
 	p += 4
 	m.OptParamLen = b[p]
 	p++
-	if m.OptParamLen != 0 {
-		if m.OptionalParameters, m.Capabilities, err = UnmarshalBGPTLV(b[p : p+int(m.OptParamLen)]); err != nil {
+	l := int(m.OptParamLen)
+	extended := false
+	if m.OptParamLen == ExtOptParamType && p < len(b) && b[p] == ExtOptParamType {
+		// Non-Ext OP Type is followed by 2 bytes Extended Opt. Parm. Length
+		if p+3 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal extended optional parameters length")
+		}
+		m.ExtOptParamLen = binary.BigEndian.Uint16(b[p+1 : p+3])
+		p += 3
+		l = int(m.ExtOptParamLen)
+		extended = true
+	}
+	if p+l > len(b) {
+		return nil, fmt.Errorf("invalid optional parameters length %d", l)
+	}
+	if l != 0 {
+		if m.OptionalParameters, m.Capabilities, err = unmarshalBGPTLV(b[p:p+l], extended); err != nil {
 			return nil, err
 		}
 	}
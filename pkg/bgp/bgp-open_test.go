@@ -1,6 +1,7 @@
 package bgp
 
 import (
+	"encoding/binary"
 	"reflect"
 	"testing"
 
@@ -161,3 +162,162 @@ func TestAddPathCapability(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiLabelCapability(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect map[int]uint8
+	}{
+		{
+			name: "ipv4 labeled unicast 3 labels",
+			// Optional parameter with Multiprotocol Extensions IPv4 LU and Multiple Labels IPv4 LU count 3
+			input:  []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x0e, 0x02, 0x0c, 0x01, 0x04, 0x00, 0x01, 0x00, 0x04, 0x08, 0x04, 0x00, 0x01, 0x04, 0x03},
+			expect: map[int]uint8{NLRIMessageType(1, 4): 3},
+		},
+		{
+			name:   "no multiple labels capability",
+			input:  []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x08, 0x02, 0x06, 0x01, 0x04, 0x00, 0x01, 0x00, 0x04},
+			expect: map[int]uint8{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om, err := UnmarshalBGPOpenMessage(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal open message with error: %+v", err)
+			}
+			got := om.MultiLabelCapability()
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected multiple labels %+v, got %+v", tt.expect, got)
+			}
+			if om.IsMultiLabelCapable() != (len(tt.expect) != 0) {
+				t.Fatalf("expected multiple labels capable %t", len(tt.expect) != 0)
+			}
+		})
+	}
+}
+
+func TestRouteRefreshCapability(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		refresh  bool
+		enhanced bool
+	}{
+		{
+			name: "route refresh and enhanced route refresh",
+			// Optional parameter with Route Refresh and Enhanced Route Refresh capabilities
+			input:    []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x06, 0x02, 0x04, 0x02, 0x00, 0x46, 0x00},
+			refresh:  true,
+			enhanced: true,
+		},
+		{
+			name: "prestandard route refresh",
+			// Optional parameter with Prestandard Route Refresh capability
+			input:   []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x04, 0x02, 0x02, 0x80, 0x00},
+			refresh: true,
+		},
+		{
+			name:  "no route refresh",
+			input: []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x08, 0x02, 0x06, 0x01, 0x04, 0x00, 0x01, 0x00, 0x04},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om, err := UnmarshalBGPOpenMessage(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal open message with error: %+v", err)
+			}
+			if got := om.IsRouteRefreshCapable(); got != tt.refresh {
+				t.Fatalf("expected route refresh capable %t, got %t", tt.refresh, got)
+			}
+			if got := om.IsEnhancedRouteRefreshCapable(); got != tt.enhanced {
+				t.Fatalf("expected enhanced route refresh capable %t, got %t", tt.enhanced, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBGPOpenMessageExtendedOptParams(t *testing.T) {
+	// 50 Multiprotocol Extensions capabilities, each in own optional parameter, do not fit
+	// 1 byte Optional Parameters Length and require RFC 9072 encoding.
+	params := make([]byte, 0)
+	for i := 0; i < 50; i++ {
+		params = append(params, 2, 0, 6, 1, 4, 0, 1, 0, byte(i+1))
+	}
+	input := []byte{0, 0, 1, 4, 19, 206, 0, 90, 192, 168, 8, 8, ExtOptParamType, ExtOptParamType, byte(len(params) >> 8), byte(len(params))}
+	input = append(input, params...)
+	binary.BigEndian.PutUint16(input[0:2], uint16(len(input)+16))
+
+	message, err := UnmarshalBGPOpenMessage(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal extended length open message with error: %+v", err)
+	}
+	if message.OptParamLen != ExtOptParamType {
+		t.Fatalf("expected optional parameters length %d, got %d", ExtOptParamType, message.OptParamLen)
+	}
+	if int(message.ExtOptParamLen) != len(params) {
+		t.Fatalf("expected extended optional parameters length %d, got %d", len(params), message.ExtOptParamLen)
+	}
+	mp, ok := message.Capabilities[1]
+	if !ok || len(mp) != 50 {
+		t.Fatalf("expected 50 multiprotocol extensions capabilities, got %d", len(mp))
+	}
+	for i, c := range mp {
+		if !reflect.DeepEqual([]byte{0, 1, 0, byte(i + 1)}, c.Value) {
+			t.Fatalf("capability %d value %+v does not match afi 1 safi %d", i, c.Value, i+1)
+		}
+	}
+	// Extended length of optional parameters exceeding the message must fail
+	if _, err := UnmarshalBGPOpenMessage(input[:len(input)-1]); err == nil {
+		t.Fatal("expected truncated extended length open message to fail but succeeded")
+	}
+}
+
+func TestHostnameCapability(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		host   string
+		domain string
+		ok     bool
+	}{
+		{
+			name: "hostname and domain name",
+			// Optional parameter with FQDN capability carrying host name r1 and domain name example.com
+			input:  []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x13, 0x02, 0x11, 0x49, 0x0f, 0x02, 0x72, 0x31, 0x0b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d},
+			host:   "r1",
+			domain: "example.com",
+			ok:     true,
+		},
+		{
+			name: "hostname with empty domain name",
+			// Optional parameter with FQDN capability carrying host name r1 and empty domain name
+			input: []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x06, 0x02, 0x04, 0x49, 0x04, 0x02, 0x72, 0x31, 0x00},
+			host:  "r1",
+			ok:    true,
+		},
+		{
+			name: "malformed hostname",
+			// Optional parameter with FQDN capability with host name length exceeding the capability
+			input: []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x05, 0x02, 0x03, 0x49, 0x01, 0x05},
+		},
+		{
+			name:  "no hostname capability",
+			input: []byte{0x00, 0x00, 0x01, 0x04, 0xfd, 0xe8, 0x00, 0xb4, 0x0a, 0x00, 0x00, 0x01, 0x08, 0x02, 0x06, 0x01, 0x04, 0x00, 0x01, 0x00, 0x04},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			om, err := UnmarshalBGPOpenMessage(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal open message with error: %+v", err)
+			}
+			host, domain, ok := om.GetHostname()
+			if ok != tt.ok || host != tt.host || domain != tt.domain {
+				t.Fatalf("expected hostname %q domain %q %t, got %q %q %t", tt.host, tt.domain, tt.ok, host, domain, ok)
+			}
+		})
+	}
+}
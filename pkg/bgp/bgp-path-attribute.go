@@ -7,12 +7,23 @@ import (
 	"github.com/sbezverk/tools"
 )
 
+// Path attribute flags, RFC 4271 section 4.3
+const (
+	AttrFlagOptional       = 0x80
+	AttrFlagTransitive     = 0x40
+	AttrFlagPartial        = 0x20
+	AttrFlagExtendedLength = 0x10
+)
+
 // PathAttribute defines a structure of an attribute
 type PathAttribute struct {
 	AttributeTypeFlags uint8
 	AttributeType      uint8
 	AttributeLength    uint16
 	Attribute          []byte
+	// Partial is set when an optional transitive attribute has traversed at least one router
+	// which does not support it. It is excluded from json, as it is already carried by the flags.
+	Partial bool `json:"-"`
 }
 
 // UnmarshalBGPPathAttributes builds BGP Path attributes slice
@@ -28,7 +39,7 @@ func UnmarshalBGPPathAttributes(b []byte) ([]PathAttribute, error) {
 		p += 2
 		var l uint16
 		// Checking for Extened
-		if f&0x10 == 0x10 {
+		if f&AttrFlagExtendedLength == AttrFlagExtendedLength {
 			l = binary.BigEndian.Uint16(b[p : p+2])
 			p += 2
 		} else {
@@ -39,6 +50,7 @@ func UnmarshalBGPPathAttributes(b []byte) ([]PathAttribute, error) {
 			AttributeTypeFlags: f,
 			AttributeType:      t,
 			AttributeLength:    l,
+			Partial:            f&AttrFlagPartial == AttrFlagPartial,
 		}
 		pa.Attribute = make([]byte, int(l))
 		copy(pa.Attribute, b[p:p+int(l)])
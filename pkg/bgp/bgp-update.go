@@ -4,11 +4,15 @@ import (
 	"crypto/md5"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/gobmp/pkg/bgpls"
+	"github.com/sbezverk/gobmp/pkg/evpn"
+	"github.com/sbezverk/gobmp/pkg/l3vpn"
 	"github.com/sbezverk/gobmp/pkg/prefixsid"
+	"github.com/sbezverk/gobmp/pkg/srpolicy"
 	"github.com/sbezverk/tools"
 )
 
@@ -16,8 +20,62 @@ const (
 	MP_REACH_NLRI   = 14
 	MP_UNREACH_NLRI = 15
 	BGP4_NLRI       = 0
+	ATTR_SET        = 128
 )
 
+// ErrAttributeNotFound is returned by accessors of path attributes when BGP Update does not carry
+// the attribute, it lets callers tell an absent attribute from a malformed one.
+var ErrAttributeNotFound = errors.New("attribute not found")
+
+// TrailingBytesError is returned when bytes remain in BGP Update after the last complete NLRI prefix,
+// it indicates a framing error by the originator of the update. It is returned only for Updates decoded
+// with SessionContext carrying AddPath, without it NLRI encoding is not known.
+type TrailingBytesError struct {
+	Count int
+}
+
+func (e *TrailingBytesError) Error() string {
+	return fmt.Sprintf("%d trailing bytes found after the end of NLRI", e.Count)
+}
+
+// MissingAttributeError is returned when BGP Update announcing routes does not carry
+// one of well-known mandatory attributes.
+type MissingAttributeError struct {
+	AttributeType uint8
+}
+
+func (e *MissingAttributeError) Error() string {
+	return fmt.Sprintf("missing mandatory attribute %d", e.AttributeType)
+}
+
+// AttributeLengthError is returned when Total Path Attribute Length of BGP Update does not match
+// the sum of lengths of path attributes found in the update, when it exceeds the update itself,
+// AttributesLength carries the number of bytes left in the update.
+type AttributeLengthError struct {
+	TotalPathAttributeLength uint16
+	AttributesLength         int
+}
+
+func (e *AttributeLengthError) Error() string {
+	return fmt.Sprintf("total path attribute length %d does not match %d bytes of path attributes", e.TotalPathAttributeLength, e.AttributesLength)
+}
+
+// AttributeError defines a path attribute which failed to decode, it is collected in Update's AttributeErrors
+// when requested by SessionContext's CollectAttributeErrors.
+type AttributeError struct {
+	AttributeType uint8
+	Err           error
+}
+
+func (e *AttributeError) Error() string {
+	return fmt.Sprintf("failed to decode attribute %d: %+v", e.AttributeType, e.Err)
+}
+
+// Unwrap returns the error the attribute failed to decode with
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
 // Update defines a structure of BGP Update message
 type Update struct {
 	WithdrawnRoutesLength    uint16
@@ -26,6 +84,16 @@ type Update struct {
 	PathAttributes           []PathAttribute
 	NLRI                     []byte
 	BaseAttributes           *BaseAttributes
+	// RawNLRI and RawWithdrawnNLRI carry copies of NLRI bytes of MP_REACH_NLRI and MP_UNREACH_NLRI
+	// attributes exactly as found on the wire, keyed by address family, they are set only when
+	// requested by SessionContext's RawNLRI.
+	RawNLRI          map[AFISAFI][]byte
+	RawWithdrawnNLRI map[AFISAFI][]byte
+	// AttributeErrors carries path attributes which failed to decode and were excluded from the Update,
+	// it is set only when requested by SessionContext's CollectAttributeErrors.
+	AttributeErrors []AttributeError
+	// ctx carries parameters of the session the Update was decoded with, nil if they are not known
+	ctx *SessionContext
 }
 
 // GetAllAttributeID return a slixe of int with all attributes found in BGP Update
@@ -38,6 +106,18 @@ func (up *Update) GetAllAttributeID() []uint8 {
 	return attrs
 }
 
+// GetPartialAttributeID returns a slice with types of all attributes with Partial bit set
+func (up *Update) GetPartialAttributeID() []uint8 {
+	attrs := make([]uint8, 0)
+	for _, attr := range up.PathAttributes {
+		if attr.Partial {
+			attrs = append(attrs, attr.AttributeType)
+		}
+	}
+
+	return attrs
+}
+
 // GetBaseAttrHash calculates 16 bytes MD5 Hash of all available base attributes.
 func (up *Update) GetBaseAttrHash() string {
 	data, err := json.Marshal(&up.PathAttributes)
@@ -79,6 +159,81 @@ func (up *Update) GetAttrPrefixSID() (*prefixsid.PSid, error) {
 	return nil, fmt.Errorf("not found")
 }
 
+// GetAttrTunnelEncapsulation check for presense of BGP Attribute Tunnel Encapsulation (23) and instantiates it
+func (up *Update) GetAttrTunnelEncapsulation() (*TunnelEncapsulation, error) {
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType == TUNNEL_ENCAP {
+			return UnmarshalTunnelEncapsulation(attr.Attribute)
+		}
+	}
+	return nil, ErrAttributeNotFound
+}
+
+// GetAttrPMSITunnel check for presense of BGP Attribute PMSI Tunnel (22) and instantiates it
+func (up *Update) GetAttrPMSITunnel() (*PMSITunnel, error) {
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType == PMSI_TUNNEL {
+			return UnmarshalPMSITunnel(attr.Attribute)
+		}
+	}
+	return nil, ErrAttributeNotFound
+}
+
+// GetAttrSet check for presense of BGP Attribute ATTR_SET (128) and instantiates it, VPN routes imported
+// from CE carry in it the attributes as seen by CE.
+func (up *Update) GetAttrSet() (*l3vpn.AttrSet, error) {
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType == ATTR_SET {
+			return l3vpn.UnmarshalAttrSet(attr.Attribute)
+		}
+	}
+	return nil, ErrAttributeNotFound
+}
+
+// GetSRPolicy check for presense of SR Policy NLRI (SAFI 73) in MP_REACH_NLRI or MP_UNREACH_NLRI and instantiates
+// SR Policy candidate path combining the NLRI with SR Policy TLV of Tunnel Encapsulation attribute, for withdrawn
+// candidate paths Withdrawn is set.
+func (up *Update) GetSRPolicy() (*srpolicy.Policy, error) {
+	for _, attr := range up.PathAttributes {
+		if len(attr.Attribute) < 3 || attr.Attribute[2] != 73 {
+			continue
+		}
+		var mp MPNLRI
+		var err error
+		switch attr.AttributeType {
+		case MP_REACH_NLRI:
+			mp, err = UnmarshalMPReachNLRI(attr.Attribute, false, nil)
+		case MP_UNREACH_NLRI:
+			mp, err = UnmarshalMPUnReachNLRI(attr.Attribute, nil)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		nlri, err := mp.GetNLRI73()
+		if err != nil {
+			return nil, err
+		}
+		if attr.AttributeType == MP_UNREACH_NLRI {
+			p, err := srpolicy.NewPolicy(nlri, nil)
+			if err != nil {
+				return nil, err
+			}
+			p.Withdrawn = true
+			return p, nil
+		}
+		var tlv *srpolicy.TLV
+		if up.BaseAttributes != nil {
+			if tlv, err = srpolicy.UnmarshalSRPolicyTLV(up.BaseAttributes.TunnelEncapAttr); err != nil {
+				return nil, err
+			}
+		}
+		return srpolicy.NewPolicy(nlri, tlv)
+	}
+	return nil, ErrAttributeNotFound
+}
+
 // HasPrefixSID check for presense of BGP Attribute Prefix SID (40) and returns true is found
 func (up *Update) HasPrefixSID() bool {
 	for _, attr := range up.PathAttributes {
@@ -90,6 +245,203 @@ func (up *Update) HasPrefixSID() bool {
 	return false
 }
 
+// GetAttrExtCommunity check for presense of BGP Attribute Extended Communities (16) and instantiates it
+func (up *Update) GetAttrExtCommunity() ([]ExtCommunity, error) {
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType == 16 {
+			return UnmarshalBGPExtCommunity(attr.Attribute)
+		}
+	}
+	return nil, ErrAttributeNotFound
+}
+
+// GetAttrIPv6ExtCommunity check for presense of BGP Attribute IPv6 Address Specific Extended Community (25)
+// and instantiates it
+func (up *Update) GetAttrIPv6ExtCommunity() ([]IPv6ExtCommunity, error) {
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType == 25 {
+			return UnmarshalBGPIPv6ExtCommunity(attr.Attribute)
+		}
+	}
+	return nil, ErrAttributeNotFound
+}
+
+// HasDefaultGateway check for presense of EVPN Default Gateway Extended Community in BGP Attribute
+// Extended Communities (16) and returns true if found
+func (up *Update) HasDefaultGateway() bool {
+	ext, err := up.GetAttrExtCommunity()
+	if err != nil {
+		return false
+	}
+	for _, e := range ext {
+		if e.IsDefaultGateway() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetSiteOfOrigin returns values of all Route Origin (Site of Origin) extended communities carried
+// by BGP Update, nil is returned if there are none.
+func (up *Update) GetSiteOfOrigin() []string {
+	exts, err := up.GetAttrExtCommunity()
+	if err != nil {
+		return nil
+	}
+	var soo []string
+	for _, ext := range exts {
+		if ro, ok := ext.GetRouteOrigin(); ok {
+			soo = append(soo, ro)
+		}
+	}
+
+	return soo
+}
+
+// GetRouterMAC returns the MAC address of EVPN Router's MAC Extended Community and true,
+// if BGP Update does not carry it, false is returned.
+func (up *Update) GetRouterMAC() (*evpn.MACAddress, bool) {
+	exts, err := up.GetAttrExtCommunity()
+	if err != nil {
+		return nil, false
+	}
+	for _, ext := range exts {
+		if mac, ok := ext.GetRouterMAC(); ok {
+			return mac, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetEVPNL2Attributes returns the values of EVPN Layer 2 Attributes Extended Community and true,
+// if BGP Update does not carry it, false is returned.
+func (up *Update) GetEVPNL2Attributes() (*EVPNL2Attributes, bool) {
+	exts, err := up.GetAttrExtCommunity()
+	if err != nil {
+		return nil, false
+	}
+	for _, ext := range exts {
+		if l2, ok := ext.GetEVPNL2Attributes(); ok {
+			return l2, true
+		}
+	}
+
+	return nil, false
+}
+
+// GetConsistentHashSortOrder returns the order value of Consistent Hash Sort Order Extended Community
+// and true, if BGP Update does not carry it, false is returned.
+func (up *Update) GetConsistentHashSortOrder() (uint32, bool) {
+	exts, err := up.GetAttrExtCommunity()
+	if err != nil {
+		return 0, false
+	}
+	for _, ext := range exts {
+		if o, ok := ext.GetConsistentHashSortOrder(); ok {
+			return o, true
+		}
+	}
+
+	return 0, false
+}
+
+// GetSymmetricIRB returns L3 VNI of EVPN route and MAC of the advertising router, which together are
+// used to build the inner Ethernet header for symmetric IRB forwarding, false is returned when either
+// of them is not present.
+func (up *Update) GetSymmetricIRB(route *evpn.NLRI) (uint32, *evpn.MACAddress, bool) {
+	vni, ok := route.GetL3VNI()
+	if !ok {
+		return 0, nil, false
+	}
+	mac, ok := up.GetRouterMAC()
+	if !ok {
+		return 0, nil, false
+	}
+
+	return vni, mac, true
+}
+
+// GetEffectiveNextHop returns the next hop to be used for forwarding routes of BGP Update and true, false is
+// returned when no next hop is found. The next hop is resolved with the following precedence:
+//  1. NEXT_HOP carried inside of ATTR_SET, for VPN routes imported from CE it is the next hop as seen by CE,
+//     https://www.rfc-editor.org/rfc/rfc6368#section-5
+//  2. Next hop of MP_REACH_NLRI, RFC 4760
+//  3. Legacy NEXT_HOP attribute, RFC 4271
+func (up *Update) GetEffectiveNextHop() (string, bool) {
+	if as, err := up.GetAttrSet(); err == nil {
+		if nh, ok := as.GetAttribute(3); ok && (len(nh) == 4 || len(nh) == 16) {
+			return unmarshalAttrNextHop(nh), true
+		}
+	}
+	for _, attr := range up.PathAttributes {
+		if attr.AttributeType != MP_REACH_NLRI {
+			continue
+		}
+		b := attr.Attribute
+		if len(b) < 5 || 5+int(b[3]) > len(b) || b[3] == 0 {
+			break
+		}
+		mp, err := UnmarshalMPReachNLRI(b, up.HasPrefixSID(), nil)
+		if err != nil {
+			break
+		}
+		return mp.GetNextHop(), true
+	}
+	if up.BaseAttributes != nil && up.BaseAttributes.Nexthop != "" {
+		return up.BaseAttributes.Nexthop, true
+	}
+
+	return "", false
+}
+
+// CheckMandatoryAttributes validates presence of well-known mandatory attributes, ORIGIN and AS_PATH
+// are required for any update announcing routes, NEXT_HOP is required only when routes are carried
+// in the legacy NLRI field, for MP-only updates the next hop is carried in MP_REACH_NLRI, RFC 4760.
+// Updates carrying only withdrawals do not require any attributes.
+func (up *Update) CheckMandatoryAttributes() error {
+	present := make(map[uint8]bool)
+	for _, attr := range up.PathAttributes {
+		present[attr.AttributeType] = true
+	}
+	if len(up.NLRI) == 0 && !present[MP_REACH_NLRI] {
+		return nil
+	}
+	mandatory := []uint8{1, 2}
+	if len(up.NLRI) != 0 {
+		mandatory = append(mandatory, 3)
+	}
+	for _, t := range mandatory {
+		if !present[t] {
+			return &MissingAttributeError{AttributeType: t}
+		}
+	}
+
+	return nil
+}
+
+// IsEndOfRIB returns address family and true if BGP Update is End-of-RIB marker, RFC 4724 section 2,
+// for IPv4 Unicast it is an Update without withdrawn routes, path attributes and NLRI, for other address
+// families it is an Update carrying only MP_UNREACH_NLRI attribute without withdrawn routes.
+func (up *Update) IsEndOfRIB() (AFISAFI, bool) {
+	if len(up.WithdrawnRoutes) != 0 || len(up.NLRI) != 0 {
+		return AFISAFI{}, false
+	}
+	switch len(up.PathAttributes) {
+	case 0:
+		return AFISAFI{AFI: 1, SAFI: 1}, true
+	case 1:
+		attr := up.PathAttributes[0]
+		if attr.AttributeType != MP_UNREACH_NLRI || len(attr.Attribute) != 3 {
+			return AFISAFI{}, false
+		}
+		return AFISAFI{AFI: binary.BigEndian.Uint16(attr.Attribute[0:2]), SAFI: attr.Attribute[2]}, true
+	}
+
+	return AFISAFI{}, false
+}
+
 func (up *Update) GetNLRIType() (uint8, int) {
 	if len(up.PathAttributes) == 0 {
 		// Fall back to default NLRI
@@ -107,34 +459,205 @@ func (up *Update) GetNLRIType() (uint8, int) {
 	return BGP4_NLRI, 0
 }
 
-// UnmarshalBGPUpdate build BGP Update object from the byte slice provided
+// UnmarshalBGPUpdate build BGP Update object from the byte slice provided, since the session's
+// 4-octet AS capability is not known, AS_PATH encoding is detected from the attribute's content.
 func UnmarshalBGPUpdate(b []byte) (*Update, error) {
+	return UnmarshalBGPUpdateWithContext(b, nil)
+}
+
+// UnmarshalBGPUpdateWithContext build BGP Update object from the byte slice provided using parameters
+// negotiated for the session, if ctx is nil, it behaves as UnmarshalBGPUpdate.
+func UnmarshalBGPUpdateWithContext(b []byte, ctx *SessionContext) (*Update, error) {
 	if glog.V(6) {
 		glog.Infof("BGPUpdate Raw: %s", tools.MessageHex(b))
 	}
 	p := 0
-	u := Update{}
+	u := Update{ctx: ctx}
+	if len(b) < 4 {
+		return nil, fmt.Errorf("not enough bytes to unmarshal bgp update")
+	}
 	u.WithdrawnRoutesLength = binary.BigEndian.Uint16(b[p : p+2])
 	p += 2
+	if p+int(u.WithdrawnRoutesLength)+2 > len(b) {
+		return nil, fmt.Errorf("invalid withdrawn routes length %d", u.WithdrawnRoutesLength)
+	}
 	u.WithdrawnRoutes = make([]byte, u.WithdrawnRoutesLength)
 	copy(u.WithdrawnRoutes, b[p:p+int(u.WithdrawnRoutesLength)])
 	p += int(u.WithdrawnRoutesLength)
 	u.TotalPathAttributeLength = binary.BigEndian.Uint16(b[p : p+2])
 	p += 2
+	// Total Path Attribute Length cannot go beyond the end of the update and path attributes
+	// must fill exactly Total Path Attribute Length bytes
+	if p+int(u.TotalPathAttributeLength) > len(b) {
+		return nil, &AttributeLengthError{TotalPathAttributeLength: u.TotalPathAttributeLength, AttributesLength: len(b) - p}
+	}
+	if l := pathAttributesLength(b[p : p+int(u.TotalPathAttributeLength)]); l != int(u.TotalPathAttributeLength) {
+		return nil, &AttributeLengthError{TotalPathAttributeLength: u.TotalPathAttributeLength, AttributesLength: l}
+	}
 	attrs, err := UnmarshalBGPPathAttributes(b[p : p+int(u.TotalPathAttributeLength)])
 	if err != nil {
 		return nil, err
 	}
+	ab := b[p : p+int(u.TotalPathAttributeLength)]
+	if ctx != nil && ctx.CollectAttributeErrors {
+		attrs, u.AttributeErrors = filterAttributes(attrs, ctx)
+		ab = serializePathAttributes(attrs)
+	}
 	// Building BGP's update Base attributes struct which is common to all messages
-	baseAttrs, err := UnmarshalBGPBaseAttributes(b[p : p+int(u.TotalPathAttributeLength)])
+	baseAttrs, err := unmarshalBGPBaseAttributes(ab, ctx)
 	if err != nil {
 		return nil, err
 	}
 	u.PathAttributes = attrs
 	u.BaseAttributes = baseAttrs
+	if ctx != nil && ctx.RawNLRI {
+		u.RawNLRI, u.RawWithdrawnNLRI = rawMPNLRI(attrs)
+	}
 	p += int(u.TotalPathAttributeLength)
+	// NLRI must consume exactly to the end of the message, if it does not, the leftover bytes
+	// are reported as a framing error. NLRI encoding depends on Add Path capability, so the check
+	// is done only when the session's capability is known.
+	if ctx != nil && ctx.AddPath != nil {
+		if n := nlriTrailingBytes(b[p:], ctx.AddPath[NLRIMessageType(1, 1)]); n != 0 {
+			return nil, &TrailingBytesError{Count: n}
+		}
+	}
 	u.NLRI = make([]byte, len(b)-p)
 	copy(u.NLRI, b[p:])
 
 	return &u, nil
 }
+
+// filterAttributes decodes each path attribute and returns the attributes which decoded successfully and
+// the errors of the attributes which did not.
+func filterAttributes(attrs []PathAttribute, ctx *SessionContext) ([]PathAttribute, []AttributeError) {
+	good := make([]PathAttribute, 0, len(attrs))
+	errs := make([]AttributeError, 0)
+	for _, attr := range attrs {
+		if err := decodeAttribute(attr.AttributeType, attr.Attribute, ctx); err != nil {
+			errs = append(errs, AttributeError{AttributeType: attr.AttributeType, Err: err})
+			continue
+		}
+		good = append(good, attr)
+	}
+
+	return good, errs
+}
+
+// decodeAttribute validates attribute of type t per RFC 7606 and decodes the attributes gobmp instantiates
+// from the update, AS_PATH is decoded with the AS width of ctx. nil is returned for valid and for not decoded
+// attributes.
+func decodeAttribute(t uint8, b []byte, ctx *SessionContext) error {
+	if err := checkAttribute(t, b); err != nil {
+		return err
+	}
+	var err error
+	switch t {
+	case 2:
+		_, err = UnmarshalASPathWithContext(b, ctx)
+	case PMSI_TUNNEL:
+		_, err = UnmarshalPMSITunnel(b)
+	case TUNNEL_ENCAP:
+		_, err = UnmarshalTunnelEncapsulation(b)
+	case 25:
+		_, err = UnmarshalBGPIPv6ExtCommunity(b)
+	case 29:
+		_, err = bgpls.UnmarshalBGPLSNLRI(b)
+	case 40:
+		_, err = prefixsid.UnmarshalBGPAttrPrefixSID(b)
+	case ATTR_SET:
+		_, err = l3vpn.UnmarshalAttrSet(b)
+	}
+
+	return err
+}
+
+// serializePathAttributes builds the wire format of path attributes
+func serializePathAttributes(attrs []PathAttribute) []byte {
+	b := make([]byte, 0)
+	for _, attr := range attrs {
+		b = append(b, attr.AttributeTypeFlags, attr.AttributeType)
+		if attr.AttributeTypeFlags&AttrFlagExtendedLength == AttrFlagExtendedLength {
+			b = binary.BigEndian.AppendUint16(b, uint16(len(attr.Attribute)))
+		} else {
+			b = append(b, byte(len(attr.Attribute)))
+		}
+		b = append(b, attr.Attribute...)
+	}
+
+	return b
+}
+
+// rawMPNLRI returns copies of NLRI bytes of MP_REACH_NLRI and MP_UNREACH_NLRI attributes keyed by
+// address family, attributes too short to carry NLRI are skipped.
+func rawMPNLRI(attrs []PathAttribute) (map[AFISAFI][]byte, map[AFISAFI][]byte) {
+	reach := make(map[AFISAFI][]byte)
+	unreach := make(map[AFISAFI][]byte)
+	for _, attr := range attrs {
+		b := attr.Attribute
+		switch attr.AttributeType {
+		case MP_REACH_NLRI:
+			// AFI, SAFI, Next Hop length, Next Hop and Reserved byte precede NLRI
+			if len(b) < 4 || 5+int(b[3]) > len(b) {
+				continue
+			}
+			k := AFISAFI{AFI: binary.BigEndian.Uint16(b[0:2]), SAFI: b[2]}
+			reach[k] = append([]byte{}, b[5+int(b[3]):]...)
+		case MP_UNREACH_NLRI:
+			// AFI and SAFI precede Withdrawn Routes
+			if len(b) < 3 {
+				continue
+			}
+			k := AFISAFI{AFI: binary.BigEndian.Uint16(b[0:2]), SAFI: b[2]}
+			unreach[k] = append([]byte{}, b[3:]...)
+		}
+	}
+
+	return reach, unreach
+}
+
+// pathAttributesLength walks path attributes and returns the sum of their lengths including headers,
+// if the last attribute does not fit, its claimed length is still counted.
+func pathAttributesLength(b []byte) int {
+	p := 0
+	for p < len(b) {
+		if p+3 > len(b) {
+			return p + 3
+		}
+		if b[p]&AttrFlagExtendedLength == AttrFlagExtendedLength {
+			if p+4 > len(b) {
+				return p + 4
+			}
+			p += 4 + int(binary.BigEndian.Uint16(b[p+2:p+4]))
+		} else {
+			p += 3 + int(b[p+2])
+		}
+	}
+
+	return p
+}
+
+// nlriTrailingBytes walks IPv4 NLRI prefixes and returns the number of bytes left over
+// after the last complete prefix.
+func nlriTrailingBytes(b []byte, pathID bool) int {
+	for p := 0; p < len(b); {
+		s := p
+		if pathID {
+			p += 4
+		}
+		if p >= len(b) {
+			return len(b) - s
+		}
+		l := int(b[p])
+		if l > 32 {
+			return len(b) - s
+		}
+		p++
+		p += (l + 7) / 8
+		if p > len(b) {
+			return len(b) - s
+		}
+	}
+
+	return 0
+}
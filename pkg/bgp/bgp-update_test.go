@@ -1,10 +1,15 @@
 package bgp
 
 import (
+	"encoding/binary"
+	"errors"
+	"net"
 	"reflect"
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/evpn"
+	"github.com/sbezverk/gobmp/pkg/srpolicy"
 )
 
 func TestUnmarshalBGPUpdate(t *testing.T) {
@@ -70,3 +75,533 @@ func TestUnmarshalBGPUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalBGPUpdateTrailingBytes(t *testing.T) {
+	session := &SessionContext{AddPath: map[int]bool{}}
+	addPathSession := &SessionContext{AddPath: map[int]bool{NLRIMessageType(1, 1): true}}
+	tests := []struct {
+		name   string
+		input  []byte
+		ctx    *SessionContext
+		expect int
+	}{
+		{
+			name:   "no trailing bytes",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x18, 0x0a, 0x00, 0x82},
+			ctx:    session,
+			expect: 0,
+		},
+		{
+			name:   "two trailing bytes",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x18, 0x0a, 0x00, 0x82, 0xff, 0xff},
+			ctx:    session,
+			expect: 2,
+		},
+		{
+			name:   "truncated last prefix",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x18, 0x0a, 0x00, 0x82, 0x18, 0x0a},
+			ctx:    session,
+			expect: 2,
+		},
+		{
+			name:   "trailing bytes not checked without session",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x18, 0x0a, 0x00, 0x82, 0xff, 0xff},
+			expect: 0,
+		},
+		{
+			name:   "add path ipv4 unicast",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x64, 0x18, 0x0a, 0x00, 0x82},
+			ctx:    addPathSession,
+			expect: 0,
+		},
+		{
+			name:   "add path ipv4 unicast without session",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x64, 0x18, 0x0a, 0x00, 0x82},
+			expect: 0,
+		},
+		{
+			name:   "add path ipv4 unicast over session without add path",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x64, 0x18, 0x0a, 0x00, 0x82},
+			ctx:    session,
+			expect: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalBGPUpdateWithContext(tt.input, tt.ctx)
+			if tt.expect == 0 {
+				if err != nil {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			var tErr *TrailingBytesError
+			if !errors.As(err, &tErr) {
+				t.Fatalf("expected TrailingBytesError but got: %+v", err)
+			}
+			if tErr.Count != tt.expect {
+				t.Fatalf("expected %d trailing bytes but got %d", tt.expect, tErr.Count)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBGPUpdateRawNLRI(t *testing.T) {
+	input := []byte{
+		0x00, 0x00, 0x00, 0x1f,
+		// MP_REACH_NLRI IPv4 Unicast, next hop 10.0.0.1, 10.10.10.0/24
+		0x80, 0x0e, 0x0d, 0x00, 0x01, 0x01, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x18, 0x0a, 0x0a, 0x0a,
+		// MP_UNREACH_NLRI IPv6 Unicast, 2001:db8::/64
+		0x80, 0x0f, 0x0c, 0x00, 0x02, 0x01, 0x40, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00,
+	}
+	reach := map[AFISAFI][]byte{
+		{AFI: 1, SAFI: 1}: input[16:20],
+	}
+	unreach := map[AFISAFI][]byte{
+		{AFI: 2, SAFI: 1}: input[26:],
+	}
+	u, err := UnmarshalBGPUpdateWithContext(input, &SessionContext{RawNLRI: true})
+	if err != nil {
+		t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+	}
+	if !reflect.DeepEqual(reach, u.RawNLRI) {
+		t.Fatalf("expected raw nlri %+v, got %+v", reach, u.RawNLRI)
+	}
+	if !reflect.DeepEqual(unreach, u.RawWithdrawnNLRI) {
+		t.Fatalf("expected raw withdrawn nlri %+v, got %+v", unreach, u.RawWithdrawnNLRI)
+	}
+	raw := u.RawNLRI[AFISAFI{AFI: 1, SAFI: 1}]
+	u, err = UnmarshalBGPUpdate(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+	}
+	if u.RawNLRI != nil || u.RawWithdrawnNLRI != nil {
+		t.Fatal("expected raw nlri not to be retained without request")
+	}
+	// Raw NLRI must not share memory with the input
+	input[16] = 0xff
+	if raw[0] != 0x18 {
+		t.Fatal("raw nlri is not a copy of the input")
+	}
+}
+
+func TestUnmarshalBGPUpdateAttributeLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		fail   bool
+		expect int
+	}{
+		{
+			name:  "valid total path attribute length",
+			input: []byte{0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x40, 0x03, 0x04, 0x0A, 0x00, 0x00, 0x01, 0x18, 0x0A, 0x0A, 0x0A},
+		},
+		{
+			name:   "total path attribute length cuts next hop attribute",
+			input:  []byte{0x00, 0x00, 0x00, 0x12, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x40, 0x03, 0x04, 0x0A, 0x00, 0x00, 0x01, 0x18, 0x0A, 0x0A, 0x0A},
+			fail:   true,
+			expect: 20,
+		},
+		{
+			name:   "total path attribute length beyond the end of update",
+			input:  []byte{0x00, 0x00, 0x00, 0x20, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x40, 0x03, 0x04, 0x0A, 0x00, 0x00, 0x01, 0x18, 0x0A, 0x0A, 0x0A},
+			fail:   true,
+			expect: 24,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if !tt.fail {
+				if err != nil {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				if u.TotalPathAttributeLength != 20 {
+					t.Fatalf("expected total path attribute length 20 but got %d", u.TotalPathAttributeLength)
+				}
+				return
+			}
+			var aErr *AttributeLengthError
+			if !errors.As(err, &aErr) {
+				t.Fatalf("expected AttributeLengthError but got: %+v", err)
+			}
+			if aErr.AttributesLength != tt.expect {
+				t.Fatalf("expected %d bytes of attributes but got %d", tt.expect, aErr.AttributesLength)
+			}
+		})
+	}
+}
+
+func TestCheckMandatoryAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		missing uint8
+	}{
+		{
+			name:  "mp only ipv6 update without next hop",
+			input: []byte{0x00, 0x00, 0x00, 0x2C, 0x40, 0x01, 0x01, 0x02, 0x40, 0x02, 0x0A, 0x02, 0x02, 0x00, 0x00, 0xFD, 0xE9, 0x00, 0x00, 0xFD, 0xEB, 0x80, 0x0E, 0x18, 0x00, 0x02, 0x01, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x0A, 0x98, 0xB7, 0x0B, 0x00, 0x10, 0x20, 0x01},
+		},
+		{
+			name:  "legacy ipv4 update with next hop",
+			input: []byte{0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x40, 0x03, 0x04, 0x0A, 0x00, 0x00, 0x01, 0x18, 0x0A, 0x0A, 0x0A},
+		},
+		{
+			name:    "legacy ipv4 update without next hop",
+			input:   []byte{0x00, 0x00, 0x00, 0x0d, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x18, 0x0A, 0x0A, 0x0A},
+			missing: 3,
+		},
+		{
+			name:    "mp only ipv6 update without as path",
+			input:   []byte{0x00, 0x00, 0x00, 0x1f, 0x40, 0x01, 0x01, 0x02, 0x80, 0x0E, 0x18, 0x00, 0x02, 0x01, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x0A, 0x98, 0xB7, 0x0B, 0x00, 0x10, 0x20, 0x01},
+			missing: 2,
+		},
+		{
+			name:  "withdrawal only",
+			input: []byte{0x00, 0x04, 0x18, 0x0A, 0x0A, 0x0A, 0x00, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			err = u.CheckMandatoryAttributes()
+			if tt.missing == 0 {
+				if err != nil {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			var mErr *MissingAttributeError
+			if !errors.As(err, &mErr) {
+				t.Fatalf("expected MissingAttributeError, got: %+v", err)
+			}
+			if mErr.AttributeType != tt.missing {
+				t.Fatalf("expected missing attribute %d, got %d", tt.missing, mErr.AttributeType)
+			}
+		})
+	}
+}
+
+func TestHasDefaultGateway(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect bool
+	}{
+		{
+			name: "route target and default gateway",
+			input: []byte{0x00, 0x00, 0x00, 0x17, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x10, 0x10,
+				0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64,
+				0x03, 0x0d, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			expect: true,
+		},
+		{
+			name: "route target only",
+			input: []byte{0x00, 0x00, 0x00, 0x0f, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x10, 0x08,
+				0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64},
+			expect: false,
+		},
+		{
+			name:   "no extended communities",
+			input:  []byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00},
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			if got := u.HasDefaultGateway(); got != tt.expect {
+				t.Fatalf("expected %t, got %t", tt.expect, got)
+			}
+			if tt.expect {
+				found := false
+				for _, c := range u.BaseAttributes.ExtCommunityList {
+					if c == ECPDefaultGateway+"0" {
+						found = true
+					}
+				}
+				if !found {
+					t.Fatalf("default gateway is not found in extended communities %v", u.BaseAttributes.ExtCommunityList)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPartialAttributeID(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []uint8
+	}{
+		{
+			name: "community marked partial",
+			input: []byte{0x00, 0x00, 0x00, 0x0b, 0x40, 0x01, 0x01, 0x00, 0xe0, 0x08, 0x04,
+				0xfd, 0xe9, 0x00, 0x64},
+			expect: []uint8{8},
+		},
+		{
+			name: "community not marked partial",
+			input: []byte{0x00, 0x00, 0x00, 0x0b, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x08, 0x04,
+				0xfd, 0xe9, 0x00, 0x64},
+			expect: []uint8{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			if got := u.GetPartialAttributeID(); !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected partial attributes %v, got %v", tt.expect, got)
+			}
+			if !reflect.DeepEqual([]string{"65001:100"}, u.BaseAttributes.CommunityList) {
+				t.Fatalf("expected community 65001:100, got %v", u.BaseAttributes.CommunityList)
+			}
+		})
+	}
+}
+
+func TestGetSymmetricIRB(t *testing.T) {
+	// Type 2 route for 00:81:c4:bc:77:8a 10.10.10.1 with L2 VNI and L3 VNI labels
+	route, err := evpn.UnmarshalEVPNNLRI([]byte{0x02, 0x28, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x20, 0x0a, 0x0a, 0x0a, 0x01, 0x18, 0xa9, 0x71, 0x18, 0xa9, 0x11})
+	if err != nil {
+		t.Fatalf("failed to unmarshal evpn nlri with error: %+v", err)
+	}
+	// Type 2 route for the same MAC without IP, carrying only L2 VNI label
+	macOnly, err := evpn.UnmarshalEVPNNLRI([]byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71})
+	if err != nil {
+		t.Fatalf("failed to unmarshal evpn nlri with error: %+v", err)
+	}
+	tests := []struct {
+		name  string
+		input []byte
+		route *evpn.NLRI
+		vni   uint32
+		mac   string
+		ok    bool
+	}{
+		{
+			name: "symmetric irb type 2 route",
+			input: []byte{0x00, 0x00, 0x00, 0x17, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x10, 0x10,
+				0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64,
+				0x06, 0x03, 0x52, 0x54, 0x00, 0x12, 0x34, 0x56},
+			route: route.Route[0],
+			vni:   0x18a911,
+			mac:   "52:54:00:12:34:56",
+			ok:    true,
+		},
+		{
+			name: "router's mac without l3 vni",
+			input: []byte{0x00, 0x00, 0x00, 0x17, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x10, 0x10,
+				0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64,
+				0x06, 0x03, 0x52, 0x54, 0x00, 0x12, 0x34, 0x56},
+			route: macOnly.Route[0],
+		},
+		{
+			name: "l3 vni without router's mac",
+			input: []byte{0x00, 0x00, 0x00, 0x0f, 0x40, 0x01, 0x01, 0x00, 0xc0, 0x10, 0x08,
+				0x00, 0x02, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64},
+			route: route.Route[0],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			vni, mac, ok := u.GetSymmetricIRB(tt.route)
+			if ok != tt.ok {
+				t.Fatalf("expected %t, got %t", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if vni != tt.vni {
+				t.Fatalf("expected l3 vni %d, got %d", tt.vni, vni)
+			}
+			if mac.String() != tt.mac {
+				t.Fatalf("expected router's mac %s, got %s", tt.mac, mac.String())
+			}
+		})
+	}
+}
+
+func TestGetEffectiveNextHop(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+		ok     bool
+	}{
+		{
+			name: "vpn route with attr_set carrying next hop",
+			input: []byte{0x00, 0x00, 0x00, 0x38,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0xc0, 0x80, 0x0b, 0x00, 0x00, 0xfd, 0xe9, 0x40, 0x03, 0x04, 0xc0, 0xa8, 0x01, 0x01,
+				0x80, 0x0e, 0x20, 0x00, 0x01, 0x80, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x01, 0x00,
+				0x70, 0x00, 0x06, 0x41, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x64, 0x0a, 0x0a, 0x0a,
+			},
+			expect: "192.168.1.1",
+			ok:     true,
+		},
+		{
+			name: "vpn route without attr_set",
+			input: []byte{0x00, 0x00, 0x00, 0x2a,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x80, 0x0e, 0x20, 0x00, 0x01, 0x80, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a, 0x00, 0x00, 0x01, 0x00,
+				0x70, 0x00, 0x06, 0x41, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x64, 0x0a, 0x0a, 0x0a,
+			},
+			expect: "10.0.0.1",
+			ok:     true,
+		},
+		{
+			name:   "legacy ipv4 update with next hop",
+			input:  []byte{0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xFD, 0xE9, 0x40, 0x03, 0x04, 0x0A, 0x00, 0x00, 0x01, 0x18, 0x0A, 0x0A, 0x0A},
+			expect: "10.0.0.1",
+			ok:     true,
+		},
+		{
+			name:  "withdrawal only",
+			input: []byte{0x00, 0x04, 0x18, 0x0A, 0x0A, 0x0A, 0x00, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			nh, ok := u.GetEffectiveNextHop()
+			if ok != tt.ok || nh != tt.expect {
+				t.Fatalf("expected next hop %q %t, got %q %t", tt.expect, tt.ok, nh, ok)
+			}
+		})
+	}
+}
+
+func TestGetSRPolicy(t *testing.T) {
+	// SR Policy color 99 endpoint 10.0.0.13, preference 68, label binding sid 900000 and two segment lists
+	tunnelEncap := []byte{0x00, 0x0F, 0x00, 0x48, 0x0C, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x0D, 0x06, 0x00, 0x00, 0xDB, 0xBA, 0x00, 0x00, 0x80, 0x00, 0x19, 0x00, 0x09, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00, 0x01, 0x06, 0x00, 0x00, 0x05, 0xDC, 0x10, 0x00, 0x80, 0x00, 0x19, 0x00, 0x09, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00, 0x01, 0x06, 0x00, 0x00, 0x05, 0xDC, 0xD0, 0x00}
+	nlri := []byte{0x60, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x63, 0x0A, 0x00, 0x00, 0x0D}
+	reach := []byte{0x00, 0x00, 0x00, 0x6f,
+		0x40, 0x01, 0x01, 0x00,
+		0x40, 0x02, 0x00,
+		0x80, 0x0e, 0x16, 0x00, 0x01, 0x49, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x00}
+	reach = append(reach, nlri...)
+	reach = append(reach, 0xc0, 0x17, 0x4c)
+	reach = append(reach, tunnelEncap...)
+	unreach := []byte{0x00, 0x00, 0x00, 0x13, 0x80, 0x0f, 0x10, 0x00, 0x01, 0x49}
+	unreach = append(unreach, nlri...)
+
+	u, err := UnmarshalBGPUpdate(reach)
+	if err != nil {
+		t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+	}
+	p, err := u.GetSRPolicy()
+	if err != nil {
+		t.Fatalf("failed to get sr policy with error: %+v", err)
+	}
+	if p.Withdrawn || p.Distinguisher != 2 || p.Color != 99 || !p.Endpoint.Equal(net.ParseIP("10.0.0.13")) {
+		t.Fatalf("unexpected sr policy key distinguisher %d color %d endpoint %s withdrawn %t", p.Distinguisher, p.Color, p.Endpoint, p.Withdrawn)
+	}
+	if p.Preference != 68 {
+		t.Fatalf("expected preference 68, got %d", p.Preference)
+	}
+	if p.BindingSID == nil || p.BindingSID.Type != srpolicy.LABELBSID || binary.BigEndian.Uint32(p.BindingSID.BSID.GetBSID()) != 900000 {
+		t.Fatalf("unexpected binding sid %+v", p.BindingSID)
+	}
+	weights := make([]uint32, 0)
+	for _, sl := range p.SegmentList {
+		if len(sl.Segment) != 2 {
+			t.Fatalf("expected 2 segments, got %d", len(sl.Segment))
+		}
+		weights = append(weights, sl.GetWeight())
+	}
+	if !reflect.DeepEqual([]uint32{1, 3}, weights) {
+		t.Fatalf("expected segment lists with weights [1 3], got %v", weights)
+	}
+
+	u, err = UnmarshalBGPUpdate(unreach)
+	if err != nil {
+		t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+	}
+	p, err = u.GetSRPolicy()
+	if err != nil {
+		t.Fatalf("failed to get withdrawn sr policy with error: %+v", err)
+	}
+	if !p.Withdrawn || p.Color != 99 || p.Preference != srpolicy.DefaultPreference || len(p.SegmentList) != 0 {
+		t.Fatalf("unexpected withdrawn sr policy %+v", p)
+	}
+}
+
+func TestUnmarshalBGPUpdateAttributeErrors(t *testing.T) {
+	// ORIGIN, AS_PATH, NEXT_HOP, COMMUNITY of invalid length 3, MED and LOCAL_PREF followed by 10.10.10.0/24
+	input := []byte{0x00, 0x00, 0x00, 0x28,
+		0x40, 0x01, 0x01, 0x00,
+		0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xfd, 0xe9,
+		0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+		0xc0, 0x08, 0x03, 0x00, 0x01, 0x02,
+		0x80, 0x04, 0x04, 0x00, 0x00, 0x00, 0x64,
+		0x40, 0x05, 0x04, 0x00, 0x00, 0x00, 0xc8,
+		0x18, 0x0a, 0x0a, 0x0a,
+	}
+	u, err := UnmarshalBGPUpdateWithContext(input, &SessionContext{AS4: true, CollectAttributeErrors: true})
+	if err != nil {
+		t.Fatalf("supposed to succeed but failed with error: %+v", err)
+	}
+	if len(u.AttributeErrors) != 1 || u.AttributeErrors[0].AttributeType != 8 {
+		t.Fatalf("expected a single error of attribute 8, got %+v", u.AttributeErrors)
+	}
+	var mErr *MalformedAttributeError
+	if !errors.As(&u.AttributeErrors[0], &mErr) {
+		t.Fatalf("expected MalformedAttributeError, got %T", u.AttributeErrors[0].Err)
+	}
+	if got := u.GetAllAttributeID(); !reflect.DeepEqual([]uint8{1, 2, 3, 4, 5}, got) {
+		t.Fatalf("expected attributes [1 2 3 4 5], got %v", got)
+	}
+	ba := u.BaseAttributes
+	if ba.Origin != "igp" || !reflect.DeepEqual([]uint32{65001}, ba.ASPath) || ba.Nexthop != "10.0.0.1" || ba.MED != 100 || ba.LocalPref != 200 || len(ba.CommunityList) != 0 {
+		t.Fatalf("unexpected base attributes %+v", *ba)
+	}
+	events, err := u.GetRIBEvents(nil)
+	if err != nil {
+		t.Fatalf("failed to get rib events with error: %+v", err)
+	}
+	if len(events) != 1 || events[0].Prefix.Prefix.String() != "10.10.10.0/24" {
+		t.Fatalf("expected rib event for 10.10.10.0/24, got %+v", events)
+	}
+}
+
+func TestAttributeNotFound(t *testing.T) {
+	// ORIGIN only
+	u, err := UnmarshalBGPUpdate([]byte{0x00, 0x00, 0x00, 0x04, 0x40, 0x01, 0x01, 0x00})
+	if err != nil {
+		t.Fatalf("supposed to succeed but failed with error: %+v", err)
+	}
+	accessors := map[string]func() error{
+		"tunnel encapsulation": func() error { _, err := u.GetAttrTunnelEncapsulation(); return err },
+		"pmsi tunnel":          func() error { _, err := u.GetAttrPMSITunnel(); return err },
+		"attr set":             func() error { _, err := u.GetAttrSet(); return err },
+		"sr policy":            func() error { _, err := u.GetSRPolicy(); return err },
+		"ext community":        func() error { _, err := u.GetAttrExtCommunity(); return err },
+		"ipv6 ext community":   func() error { _, err := u.GetAttrIPv6ExtCommunity(); return err },
+		"as path":              func() error { _, err := u.GetAttrASPath(); return err },
+		"aggregator":           func() error { _, err := u.BaseAttributes.GetAggregator(); return err },
+	}
+	for name, get := range accessors {
+		if err := get(); !errors.Is(err, ErrAttributeNotFound) {
+			t.Errorf("%s: expected ErrAttributeNotFound, got %v", name, err)
+		}
+	}
+}
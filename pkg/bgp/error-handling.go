@@ -0,0 +1,230 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ErrorAction defines the approach a BGP speaker takes when it receives BGP Update with a malformed
+// attribute, RFC 7606 section 2. Actions are ordered by severity.
+type ErrorAction int
+
+const (
+	// AttributeDiscard defines the approach where the malformed attribute is discarded and the update
+	// is processed as if the attribute was not present
+	AttributeDiscard ErrorAction = iota + 1
+	// TreatAsWithdraw defines the approach where all routes announced by the update are handled
+	// as withdrawn
+	TreatAsWithdraw
+	// SessionReset defines the approach where the session is reset with NOTIFICATION message,
+	// the update is not processed
+	SessionReset
+)
+
+// String returns the name of the error handling approach
+func (a ErrorAction) String() string {
+	switch a {
+	case AttributeDiscard:
+		return "attribute-discard"
+	case TreatAsWithdraw:
+		return "treat-as-withdraw"
+	case SessionReset:
+		return "session-reset"
+	}
+
+	return "none"
+}
+
+// MalformedAttributeError defines a malformed attribute found in BGP Update together with the approach
+// RFC 7606 defines for handling it
+type MalformedAttributeError struct {
+	AttributeType uint8
+	Action        ErrorAction
+	Reason        string
+}
+
+func (e *MalformedAttributeError) Error() string {
+	return fmt.Sprintf("malformed attribute %d, %s: %s", e.AttributeType, e.Action, e.Reason)
+}
+
+// CheckMalformedAttributes validates path attributes of BGP Update and returns a slice of found errors
+// classified per RFC 7606 section 7, empty slice is returned when no errors were found. Missing well-known
+// mandatory attributes are treated as withdraw, RFC 7606 section 3.d.
+func (up *Update) CheckMalformedAttributes() []*MalformedAttributeError {
+	errs := make([]*MalformedAttributeError, 0)
+	seen := make(map[uint8]bool)
+	for _, attr := range up.PathAttributes {
+		if seen[attr.AttributeType] {
+			// More than one instance of MP_REACH_NLRI or MP_UNREACH_NLRI requires session reset,
+			// only the first instance of any other attribute is used, RFC 7606 section 3.g
+			action := AttributeDiscard
+			if attr.AttributeType == MP_REACH_NLRI || attr.AttributeType == MP_UNREACH_NLRI {
+				action = SessionReset
+			}
+			errs = append(errs, &MalformedAttributeError{
+				AttributeType: attr.AttributeType,
+				Action:        action,
+				Reason:        "duplicate attribute",
+			})
+			continue
+		}
+		seen[attr.AttributeType] = true
+		if err := checkAttribute(attr.AttributeType, attr.Attribute); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := up.CheckMandatoryAttributes(); err != nil {
+		if mErr, ok := err.(*MissingAttributeError); ok {
+			errs = append(errs, &MalformedAttributeError{
+				AttributeType: mErr.AttributeType,
+				Action:        TreatAsWithdraw,
+				Reason:        "missing mandatory attribute",
+			})
+		}
+	}
+
+	return errs
+}
+
+// GetErrorAction returns the most severe error handling approach required by malformed attributes
+// of BGP Update and the list of errors, 0 is returned when no errors were found.
+func (up *Update) GetErrorAction() (ErrorAction, []*MalformedAttributeError) {
+	errs := up.CheckMalformedAttributes()
+	var action ErrorAction
+	for _, err := range errs {
+		if err.Action > action {
+			action = err.Action
+		}
+	}
+
+	return action, errs
+}
+
+// ApplyErrorAction returns RIB events as a BGP speaker would process them according to action,
+// for TreatAsWithdraw announcements become withdrawals, for any other action events are
+// returned unchanged.
+func ApplyErrorAction(events []RIBEvent, action ErrorAction) []RIBEvent {
+	if action != TreatAsWithdraw {
+		return events
+	}
+	withdrawn := make([]RIBEvent, 0, len(events))
+	for _, e := range events {
+		if !e.Withdraw {
+			e.Withdraw = true
+			e.NextHop = ""
+			e.Labels = nil
+		}
+		withdrawn = append(withdrawn, e)
+	}
+
+	return withdrawn
+}
+
+// checkAttribute validates a single attribute of type t, nil is returned for valid and for
+// not validated attributes.
+func checkAttribute(t uint8, b []byte) *MalformedAttributeError {
+	malformed := func(action ErrorAction, format string, a ...interface{}) *MalformedAttributeError {
+		return &MalformedAttributeError{
+			AttributeType: t,
+			Action:        action,
+			Reason:        fmt.Sprintf(format, a...),
+		}
+	}
+	switch t {
+	case 1:
+		if len(b) != 1 {
+			return malformed(TreatAsWithdraw, "invalid length %d", len(b))
+		}
+		if b[0] > 2 {
+			return malformed(TreatAsWithdraw, "undefined origin %d", b[0])
+		}
+	case 2:
+		// Encoding of ASes depends on the session, the path is malformed only if neither encoding fits
+		_, err4 := unmarshalASPath(b, true)
+		_, err2 := unmarshalASPath(b, false)
+		if err4 != nil && err2 != nil {
+			return malformed(TreatAsWithdraw, "invalid as path segments")
+		}
+	case 3, 4, 5, 9:
+		// NEXT_HOP, MULTI_EXIT_DISC, LOCAL_PREF and ORIGINATOR_ID
+		if len(b) != 4 {
+			return malformed(TreatAsWithdraw, "invalid length %d", len(b))
+		}
+	case 6:
+		if len(b) != 0 {
+			return malformed(AttributeDiscard, "invalid length %d", len(b))
+		}
+	case 7:
+		// 2 or 4 bytes AS followed by 4 bytes of IPv4 address
+		if len(b) != 6 && len(b) != 8 {
+			return malformed(AttributeDiscard, "invalid length %d", len(b))
+		}
+	case 8, 10:
+		// COMMUNITIES and CLUSTER_LIST
+		if len(b) == 0 || len(b)%4 != 0 {
+			return malformed(TreatAsWithdraw, "invalid length %d", len(b))
+		}
+	case MP_REACH_NLRI:
+		return checkMPReachNLRI(b)
+	case MP_UNREACH_NLRI:
+		if len(b) < 3 {
+			return malformed(SessionReset, "invalid length %d", len(b))
+		}
+	case 16:
+		if len(b) == 0 || len(b)%8 != 0 {
+			return malformed(TreatAsWithdraw, "invalid length %d", len(b))
+		}
+	case 17:
+		if _, err := unmarshalASPath(b, true); len(b) == 0 || err != nil {
+			return malformed(AttributeDiscard, "invalid as4 path segments")
+		}
+	case 18:
+		if len(b) != 8 {
+			return malformed(AttributeDiscard, "invalid length %d", len(b))
+		}
+	case 32:
+		if len(b) == 0 || len(b)%12 != 0 {
+			return malformed(TreatAsWithdraw, "invalid length %d", len(b))
+		}
+	}
+
+	return nil
+}
+
+// checkMPReachNLRI validates MP_REACH_NLRI attribute, when NLRI cannot be located the session must be reset,
+// next hop of unexpected length for Unicast, Multicast and Labeled Unicast address families is treated
+// as withdraw, RFC 7606 section 7.11.
+func checkMPReachNLRI(b []byte) *MalformedAttributeError {
+	if len(b) < 5 || 5+int(b[3]) > len(b) {
+		return &MalformedAttributeError{
+			AttributeType: MP_REACH_NLRI,
+			Action:        SessionReset,
+			Reason:        fmt.Sprintf("nlri cannot be located in attribute of length %d", len(b)),
+		}
+	}
+	afi := binary.BigEndian.Uint16(b[0:2])
+	safi := b[2]
+	nhl := int(b[3])
+	if safi != 1 && safi != 2 && safi != 4 {
+		return nil
+	}
+	valid := false
+	switch afi {
+	case 1:
+		// IPv4 or, with Extended Next Hop Encoding, IPv6 next hop, RFC 8950
+		valid = nhl == 4 || nhl == 16 || nhl == 32
+	case 2:
+		valid = nhl == 16 || nhl == 32
+	default:
+		return nil
+	}
+	if !valid {
+		return &MalformedAttributeError{
+			AttributeType: MP_REACH_NLRI,
+			Action:        TreatAsWithdraw,
+			Reason:        fmt.Sprintf("invalid next hop length %d for afi %d safi %d", nhl, afi, safi),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckMalformedAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		action ErrorAction
+		expect []*MalformedAttributeError
+	}{
+		{
+			name: "valid update",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x14,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0x88, 0x38,
+				0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x18, 0x0a, 0x0a, 0x0a,
+			},
+			expect: []*MalformedAttributeError{},
+		},
+		{
+			name: "mp_reach_nlri with invalid next hop length",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x18,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x80, 0x0e, 0x0e, 0x00, 0x01, 0x01, 0x05, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x18, 0x0a, 0x0a, 0x0a,
+			},
+			action: TreatAsWithdraw,
+			expect: []*MalformedAttributeError{
+				{AttributeType: MP_REACH_NLRI, Action: TreatAsWithdraw, Reason: "invalid next hop length 5 for afi 1 safi 1"},
+			},
+		},
+		{
+			name: "mp_reach_nlri next hop overruns attribute",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x0e,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x80, 0x0e, 0x04, 0x00, 0x01, 0x01, 0x10,
+			},
+			action: SessionReset,
+			expect: []*MalformedAttributeError{
+				{AttributeType: MP_REACH_NLRI, Action: SessionReset, Reason: "nlri cannot be located in attribute of length 4"},
+			},
+		},
+		{
+			name: "malformed aggregator",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x14,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0xc0, 0x07, 0x03, 0x00, 0x00, 0x65,
+				0x18, 0x0a, 0x0a, 0x0a,
+			},
+			action: AttributeDiscard,
+			expect: []*MalformedAttributeError{
+				{AttributeType: 7, Action: AttributeDiscard, Reason: "invalid length 3"},
+			},
+		},
+		{
+			name: "malformed community",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x14,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0xc0, 0x08, 0x03, 0x88, 0x38, 0x00,
+				0x18, 0x0a, 0x0a, 0x0a,
+			},
+			action: TreatAsWithdraw,
+			expect: []*MalformedAttributeError{
+				{AttributeType: 8, Action: TreatAsWithdraw, Reason: "invalid length 3"},
+			},
+		},
+		{
+			name: "duplicate local preference and missing as path",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x19,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x40, 0x05, 0x04, 0x00, 0x00, 0x00, 0x64,
+				0x40, 0x05, 0x04, 0x00, 0x00, 0x00, 0xc8,
+				0x18, 0x0a, 0x0a, 0x0a,
+			},
+			action: TreatAsWithdraw,
+			expect: []*MalformedAttributeError{
+				{AttributeType: 5, Action: AttributeDiscard, Reason: "duplicate attribute"},
+				{AttributeType: 2, Action: TreatAsWithdraw, Reason: "missing mandatory attribute"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			action, got := u.GetErrorAction()
+			if action != tt.action {
+				t.Errorf("expected action %s, got %s", tt.action, action)
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected errors do not match the actual")
+			}
+		})
+	}
+}
+
+func TestApplyErrorAction(t *testing.T) {
+	input := []byte{
+		0x00, 0x00, 0x00, 0x18,
+		0x40, 0x01, 0x01, 0x00,
+		0x40, 0x02, 0x00,
+		0x80, 0x0e, 0x0e, 0x00, 0x01, 0x01, 0x05, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x18, 0x0a, 0x0a, 0x0a,
+	}
+	u, err := UnmarshalBGPUpdate(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+	}
+	events, err := u.GetRIBEvents(nil)
+	if err != nil {
+		t.Fatalf("failed to get RIB events with error: %+v", err)
+	}
+	action, _ := u.GetErrorAction()
+	expect := []RIBEvent{
+		{
+			Prefix:   Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+			Withdraw: true,
+		},
+	}
+	got := ApplyErrorAction(events, action)
+	if !reflect.DeepEqual(expect, got) {
+		t.Logf("differences: %+v", deep.Equal(expect, got))
+		t.Fatal("the expected RIB events do not match the actual")
+	}
+}
@@ -25,6 +25,8 @@ const (
 	ECPVRFRouteImport = "vri="
 	// ECPFlowSpecRedirIPv4 extended community prefix for Flow-spec Redirect to IPv4 [draft-ietf-idr-flowspec-redirect]
 	ECPFlowSpecRedirIPv4 = "fsr="
+	// ECPFlowSpecRedirIPv6 extended community prefix for Flow-spec Redirect to IPv6 [draft-ietf-idr-flowspec-redirect-ip]
+	ECPFlowSpecRedirIPv6 = "fsr6="
 	// ECPInterAreaP2MPSegmentedNexyHop extended community prefix for Inter-Area P2MP Segmented Next-Hop	[RFC7524]
 	ECPInterAreaP2MPSegmentedNexyHop = "snh="
 	// ECPVRFRecursiveNextHop extended community prefix for VRF-Recursive-Next-Hop-Extended-Community	[Dhananjaya_Rao]
@@ -114,6 +116,8 @@ const (
 
 	// CPFlowspecTrafficRate defines Flowspec Traffic rate Sub type
 	CPFlowspecTrafficRate = "flowspec-traffic-rate="
+	// CPFlowspecTrafficRatePackets defines Flowspec Traffic rate in packets Sub type
+	CPFlowspecTrafficRatePackets = "flowspec-traffic-rate-packets="
 	// CPFlowspecTrafficAction defines Flowspec Traffic action Sub type
 	CPFlowspecTrafficAction = "flowspec-traffic-action="
 	// CPFlowspecRedirect defines Flowspec Redirect Sub type
@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/golang/glog"
+	"github.com/sbezverk/gobmp/pkg/evpn"
 	"github.com/sbezverk/tools"
 )
 
@@ -32,6 +33,196 @@ func (ext *ExtCommunity) IsRouteTarget() bool {
 	return false
 }
 
+// GetRouteOrigin returns the value of Route Origin (Site of Origin) extended community formatted as
+// administrator:assigned number and true, Route Origin is sub type 0x03 of Transitive Two-Octet AS,
+// IPv4 Address and Four-Octet AS specific types, for any other extended community false is returned.
+func (ext *ExtCommunity) GetRouteOrigin() (string, bool) {
+	return ext.getAdministratorValue(0x03)
+}
+
+// GetOSPFDomainID returns the value of OSPF Domain Identifier extended community formatted as
+// administrator:assigned number and true, OSPF Domain Identifier is sub type 0x05 of Transitive Two-Octet AS,
+// IPv4 Address and Four-Octet AS specific types, RFC 4577 section 4.2.1, for any other extended community
+// false is returned.
+func (ext *ExtCommunity) GetOSPFDomainID() (string, bool) {
+	return ext.getAdministratorValue(0x05)
+}
+
+// getAdministratorValue returns administrator:assigned number value of Transitive Two-Octet AS, IPv4 Address
+// and Four-Octet AS specific extended community of sub type subType
+func (ext *ExtCommunity) getAdministratorValue(subType uint8) (string, bool) {
+	if ext.SubType == nil || *ext.SubType != subType || len(ext.Value) != 6 {
+		return "", false
+	}
+	switch ext.Type {
+	case 0x00:
+		return fmt.Sprintf("%d:%d", binary.BigEndian.Uint16(ext.Value[0:2]), binary.BigEndian.Uint32(ext.Value[2:])), true
+	case 0x01:
+		return fmt.Sprintf("%s:%d", net.IP(ext.Value[0:4]).To4().String(), binary.BigEndian.Uint16(ext.Value[4:])), true
+	case 0x02:
+		return fmt.Sprintf("%d:%d", binary.BigEndian.Uint32(ext.Value[0:4]), binary.BigEndian.Uint16(ext.Value[4:])), true
+	}
+
+	return "", false
+}
+
+// GetEncapType returns the tunnel type carried by Encapsulation Extended Community and true,
+// for any other extended community false is returned.
+func (ext *ExtCommunity) GetEncapType() (EncapType, bool) {
+	if ext.Type != 0x03 || ext.SubType == nil || *ext.SubType != 0x0c || len(ext.Value) != 6 {
+		return 0, false
+	}
+	// 4 bytes are reserved, followed by 2 bytes of Tunnel Type
+	return EncapType(binary.BigEndian.Uint16(ext.Value[4:6])), true
+}
+
+// ColorCO defines Color-Only bits of Color Extended Community flags, they control which SR Policies
+// a route with the color can be steered into, RFC 9256 section 8.8.
+type ColorCO uint8
+
+const (
+	// ColorCOSpecificEndpoint steers the route into SR Policy with matching color and endpoint
+	// equal to the route's next hop
+	ColorCOSpecificEndpoint ColorCO = 0
+	// ColorCONullEndpoint steers the route into SR Policy with matching color and null endpoint
+	// of next hop's address family, if not available, of any address family
+	ColorCONullEndpoint ColorCO = 1
+	// ColorCOAnyEndpoint steers the route into SR Policy with matching color and any endpoint
+	// of any address family
+	ColorCOAnyEndpoint ColorCO = 2
+	// ColorCOReserved is reserved, it must be treated as ColorCOSpecificEndpoint
+	ColorCOReserved ColorCO = 3
+)
+
+// String returns a description of steering behavior signaled by CO bits
+func (co ColorCO) String() string {
+	switch co {
+	case ColorCOSpecificEndpoint:
+		return "specific-endpoint"
+	case ColorCONullEndpoint:
+		return "null-endpoint"
+	case ColorCOAnyEndpoint:
+		return "any-endpoint"
+	}
+
+	return "reserved"
+}
+
+// GetColor returns the color carried by Color Extended Community and true, for any other extended
+// community false is returned.
+func (ext *ExtCommunity) GetColor() (uint32, bool) {
+	if !ext.isColor() {
+		return 0, false
+	}
+	// 2 bytes of flags followed by 4 bytes of color
+	return binary.BigEndian.Uint32(ext.Value[2:6]), true
+}
+
+// GetColorCO returns Color-Only bits of Color Extended Community and true, the bits are 2 leftmost bits
+// of the flags, for any other extended community false is returned.
+func (ext *ExtCommunity) GetColorCO() (ColorCO, bool) {
+	if !ext.isColor() {
+		return 0, false
+	}
+
+	return ColorCO(ext.Value[0] >> 6), true
+}
+
+func (ext *ExtCommunity) isColor() bool {
+	return ext.Type == 0x03 && ext.SubType != nil && *ext.SubType == 0x0b && len(ext.Value) == 6
+}
+
+// GetConsistentHashSortOrder returns the order value of Consistent Hash Sort Order Extended Community,
+// type 0x03 sub type 0x14, and true, the value is carried in 4 bytes followed by 2 reserved bytes,
+// for any other extended community false is returned, draft-ietf-bess-service-chaining section 7.1.
+func (ext *ExtCommunity) GetConsistentHashSortOrder() (uint32, bool) {
+	if ext.Type != 0x03 || ext.SubType == nil || *ext.SubType != 0x14 || len(ext.Value) != 6 {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint32(ext.Value[0:4]), true
+}
+
+// GetTrafficRateBytes returns the rate in bytes per second of Flowspec traffic-rate-bytes action
+// and true, for any other extended community false is returned. Rate of 0 means discard all traffic.
+func (ext *ExtCommunity) GetTrafficRateBytes() (float32, bool) {
+	return ext.getTrafficRate(0x06)
+}
+
+// GetTrafficRatePackets returns the rate in packets per second of Flowspec traffic-rate-packets action
+// and true, for any other extended community false is returned. Rate of 0 means discard all traffic.
+func (ext *ExtCommunity) GetTrafficRatePackets() (float32, bool) {
+	return ext.getTrafficRate(0x0c)
+}
+
+func (ext *ExtCommunity) getTrafficRate(subType uint8) (float32, bool) {
+	if ext.Type != 0x80 || ext.SubType == nil || *ext.SubType != subType || len(ext.Value) != 6 {
+		return 0, false
+	}
+	// 2 bytes of AS followed by 4 bytes of IEEE floating point rate
+	return math.Float32frombits(binary.BigEndian.Uint32(ext.Value[2:6])), true
+}
+
+// GetTrafficMarkingDSCP returns DSCP value of Flowspec traffic-marking action and true, the matching
+// traffic gets its DSCP remarked to the value, RFC 8955 section 7.7. For any other extended community
+// false is returned.
+func (ext *ExtCommunity) GetTrafficMarkingDSCP() (uint8, bool) {
+	if ext.Type != 0x80 || ext.SubType == nil || *ext.SubType != 0x09 || len(ext.Value) != 6 {
+		return 0, false
+	}
+	// 5 bytes are reserved, DSCP is carried in 6 rightmost bits of the last byte
+	return ext.Value[5] & 0x3f, true
+}
+
+// IsDefaultGateway returns true if a specific extended community is EVPN Default Gateway, RFC 7432 section 7.8
+// defines it as Transitive Opaque Extended Community (0x03) of sub type 0x0d, the value is not used.
+func (ext *ExtCommunity) IsDefaultGateway() bool {
+	return ext.Type == 0x03 && ext.SubType != nil && *ext.SubType == 0x0d
+}
+
+// GetRouterMAC returns the MAC address carried by EVPN Router's MAC Extended Community, type 0x06
+// sub type 0x03, and true, for any other extended community false is returned, RFC 9135 section 8.1.
+func (ext *ExtCommunity) GetRouterMAC() (*evpn.MACAddress, bool) {
+	if ext.Type != 0x06 || ext.SubType == nil || *ext.SubType != 0x03 {
+		return nil, false
+	}
+	mac, err := evpn.MakeMACAddress(ext.Value)
+	if err != nil {
+		return nil, false
+	}
+
+	return mac, true
+}
+
+// EVPNL2Attributes defines the values carried by EVPN Layer 2 Attributes Extended Community, RFC 8214 section 3.1
+type EVPNL2Attributes struct {
+	// Primary is P flag, the PE is the primary PE of the Ethernet Segment
+	Primary bool `json:"primary"`
+	// Backup is B flag, the PE is the backup PE of the Ethernet Segment
+	Backup bool `json:"backup"`
+	// ControlWord is C flag, Control Word must be present when sending EVPN packets to the PE
+	ControlWord bool `json:"control_word"`
+	// MTU is L2 MTU, 0 means MTU is not checked
+	MTU uint16 `json:"mtu,omitempty"`
+}
+
+// GetEVPNL2Attributes returns the values carried by EVPN Layer 2 Attributes Extended Community, type 0x06
+// sub type 0x04, and true, for any other extended community false is returned.
+func (ext *ExtCommunity) GetEVPNL2Attributes() (*EVPNL2Attributes, bool) {
+	if ext.Type != 0x06 || ext.SubType == nil || *ext.SubType != 0x04 || len(ext.Value) != 6 {
+		return nil, false
+	}
+	// 2 bytes of Control Flags, 2 bytes of L2 MTU and 2 reserved bytes
+	flags := binary.BigEndian.Uint16(ext.Value[0:2])
+
+	return &EVPNL2Attributes{
+		Primary:     flags&0x0002 == 0x0002,
+		Backup:      flags&0x0001 == 0x0001,
+		ControlWord: flags&0x0004 == 0x0004,
+		MTU:         binary.BigEndian.Uint16(ext.Value[2:4]),
+	}, true
+}
+
 func makeExtCommunity(b []byte) (*ExtCommunity, error) {
 	ext := ExtCommunity{}
 	if len(b) != 8 {
@@ -48,16 +239,13 @@ func makeExtCommunity(b []byte) (*ExtCommunity, error) {
 		fallthrough
 	case 2:
 		fallthrough
+	case 3:
+		fallthrough
 	case 6:
 		st := uint8(b[p])
 		ext.SubType = &st
 		l = 6
 		p++
-	case 3:
-		st := uint8(b[p])
-		ext.SubType = &st
-		l = 6
-		p += 3
 	}
 	ext.Value = make([]byte, l)
 	copy(ext.Value, b[p:])
@@ -232,11 +420,13 @@ var nonTransAS2SubTypes = map[uint8]string{
 // 0x07               Flow spec traffic-action (Use of the "Value" field is defined in the "Traffic Action Fields" registry)
 // 0x08               Flow spec redirect
 // 0x09               Flow spec traffic-remarking
+// 0x0c               Flow spec traffic-rate-packets
 var flowspecSubTypes = map[uint8]string{
 	0x6: CPFlowspecTrafficRate,
 	0x7: CPFlowspecTrafficAction,
 	0x8: CPFlowspecRedirect,
 	0x9: CPFlowspecTrafficRemarking,
+	0xc: CPFlowspecTrafficRatePackets,
 }
 
 func getSubType(m map[uint8]string, subType uint8) string {
@@ -267,11 +457,16 @@ func type3(subType uint8, value []byte) string {
 	var s string
 	switch subType {
 	case 0xb:
-		s = fmt.Sprintf("%d", binary.BigEndian.Uint32(value[0:4]))
+		// 2 bytes of flags followed by 4 bytes of color
+		s = fmt.Sprintf("%d", binary.BigEndian.Uint32(value[2:6]))
 	case 0xc:
-		s = fmt.Sprintf("%d", binary.BigEndian.Uint16(value[2:4]))
-	default:
+		// 4 bytes reserved followed by 2 bytes of tunnel type
+		s = fmt.Sprintf("%d", binary.BigEndian.Uint16(value[4:6]))
+	case 0x14:
+		// 4 bytes of order value followed by 2 bytes reserved
 		s = fmt.Sprintf("%d", binary.BigEndian.Uint32(value[0:4]))
+	default:
+		s = fmt.Sprintf("%d", binary.BigEndian.Uint32(value[2:6]))
 	}
 	return getSubType(transOpaqueSubTypes, subType) + s
 }
@@ -295,6 +490,8 @@ func type6(subType uint8, value []byte) string {
 		}
 	case 0x00:
 		s = fmt.Sprintf("%d:%d", value[0], binary.BigEndian.Uint32(value[2:]))
+	case 0x04:
+		s = fmt.Sprintf("0x%04x:%d", binary.BigEndian.Uint16(value[0:2]), binary.BigEndian.Uint16(value[2:4]))
 	case 0x06:
 		s = fmt.Sprintf("%d:0x%04x", value[0], binary.BigEndian.Uint16(value[1:]))
 	default:
@@ -332,6 +529,8 @@ func type80(subType uint8, value []byte) string {
 		switch subType {
 		case 0x06:
 			s = fmt.Sprintf("AS: %d Rate: %d bps", binary.BigEndian.Uint16(value[:2]), uint32(math.Float32frombits(binary.BigEndian.Uint32(value[2:])))*8)
+		case 0x0c:
+			s = fmt.Sprintf("AS: %d Rate: %d pps", binary.BigEndian.Uint16(value[:2]), uint32(math.Float32frombits(binary.BigEndian.Uint32(value[2:]))))
 		case 0x08:
 			s = fmt.Sprintf("%d:%d", binary.BigEndian.Uint16(value[0:2]), binary.BigEndian.Uint32(value[2:]))
 		case 0x09:
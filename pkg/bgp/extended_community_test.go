@@ -1,6 +1,7 @@
 package bgp
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -21,6 +22,16 @@ func TestExtendedCommunity(t *testing.T) {
 			input:  []byte{0x00, 0x02, 0x00, 0x05, 0x00, 0x00, 0xfd, 0xeb},
 			expect: "rt=5:65003",
 		},
+		{
+			name:   "route origin 2-octet as",
+			input:  []byte{0x00, 0x03, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x0a},
+			expect: "ro=65000:10",
+		},
+		{
+			name:   "ospf domain id ipv4 address",
+			input:  []byte{0x01, 0x05, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00},
+			expect: "odi=10.0.0.1:0",
+		},
 		{
 			name:   "type 8 community",
 			input:  []byte{0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
@@ -31,6 +42,21 @@ func TestExtendedCommunity(t *testing.T) {
 			input:  []byte{0x06, 0x03, 0x0c, 0x03, 0x00, 0x00, 0x1b, 0x08},
 			expect: "rmac=0C:03:00:00:1B:08",
 		},
+		{
+			name:   "color 100",
+			input:  []byte{0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64},
+			expect: "color=100",
+		},
+		{
+			name:   "encapsulation vxlan",
+			input:  []byte{0x03, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08},
+			expect: "encap=8",
+		},
+		{
+			name:   "flowspec traffic rate packets",
+			input:  []byte{0x80, 0x0c, 0x00, 0x00, 0x46, 0x1c, 0x40, 0x00},
+			expect: "flowspec-traffic-rate-packets=AS: 0 Rate: 10000 pps",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -45,3 +71,381 @@ func TestExtendedCommunity(t *testing.T) {
 		})
 	}
 }
+
+func TestExtCommunityEncapType(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expect   EncapType
+		typeName string
+		ok       bool
+	}{
+		{
+			name:     "vxlan",
+			input:    []byte{0x03, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08},
+			expect:   EncapVXLAN,
+			typeName: "VXLAN",
+			ok:       true,
+		},
+		{
+			name:     "geneve",
+			input:    []byte{0x03, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x13},
+			expect:   EncapGENEVE,
+			typeName: "GENEVE",
+			ok:       true,
+		},
+		{
+			name:  "color is not encapsulation",
+			input: []byte{0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64},
+			ok:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			got, ok := ext.GetEncapType()
+			if ok != tt.ok {
+				t.Fatalf("expected %t, got %t", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if got != tt.expect {
+				t.Errorf("expected tunnel type %d, got %d", tt.expect, got)
+			}
+			if got.String() != tt.typeName {
+				t.Errorf("expected tunnel type name %s, got %s", tt.typeName, got.String())
+			}
+		})
+	}
+}
+
+func TestExtCommunityTrafficRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		bytes   float32
+		bytesOk bool
+		pps     float32
+		ppsOk   bool
+	}{
+		{
+			name:  "rate in packets",
+			input: []byte{0x80, 0x0c, 0x00, 0x00, 0x46, 0x1c, 0x40, 0x00},
+			pps:   10000,
+			ppsOk: true,
+		},
+		{
+			name:  "discard in packets",
+			input: []byte{0x80, 0x0c, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x00},
+			pps:   0,
+			ppsOk: true,
+		},
+		{
+			name:    "rate in bytes",
+			input:   []byte{0x80, 0x06, 0x00, 0x00, 0x46, 0x43, 0x50, 0x00},
+			bytes:   12500,
+			bytesOk: true,
+		},
+		{
+			name:  "redirect is not rate",
+			input: []byte{0x80, 0x08, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			bytes, ok := ext.GetTrafficRateBytes()
+			if ok != tt.bytesOk || bytes != tt.bytes {
+				t.Errorf("expected bytes rate %f %t, got %f %t", tt.bytes, tt.bytesOk, bytes, ok)
+			}
+			pps, ok := ext.GetTrafficRatePackets()
+			if ok != tt.ppsOk || pps != tt.pps {
+				t.Errorf("expected packets rate %f %t, got %f %t", tt.pps, tt.ppsOk, pps, ok)
+			}
+		})
+	}
+}
+
+func TestExtCommunityRouteOrigin(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+		ok     bool
+	}{
+		{
+			name:   "2-octet as route origin",
+			input:  []byte{0x00, 0x03, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x0a},
+			expect: "65000:10",
+			ok:     true,
+		},
+		{
+			name:   "ipv4 address route origin",
+			input:  []byte{0x01, 0x03, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x0a},
+			expect: "10.0.0.1:10",
+			ok:     true,
+		},
+		{
+			name:   "4-octet as route origin",
+			input:  []byte{0x02, 0x03, 0x00, 0x04, 0x03, 0xb8, 0x00, 0x0a},
+			expect: "263096:10",
+			ok:     true,
+		},
+		{
+			name:  "route target is not route origin",
+			input: []byte{0x00, 0x02, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x0a},
+		},
+		{
+			name:  "evpn router's mac is not route origin",
+			input: []byte{0x06, 0x03, 0x0c, 0x03, 0x00, 0x00, 0x1b, 0x08},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			got, ok := ext.GetRouteOrigin()
+			if ok != tt.ok || got != tt.expect {
+				t.Errorf("expected route origin %q %t, got %q %t", tt.expect, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestExtCommunityOSPFDomainID(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+		ok     bool
+	}{
+		{
+			name:   "2-octet as ospf domain id",
+			input:  []byte{0x00, 0x05, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x01},
+			expect: "65000:1",
+			ok:     true,
+		},
+		{
+			name:   "ipv4 address ospf domain id",
+			input:  []byte{0x01, 0x05, 0xc0, 0xa8, 0x01, 0x01, 0x00, 0x00},
+			expect: "192.168.1.1:0",
+			ok:     true,
+		},
+		{
+			name:  "route origin is not ospf domain id",
+			input: []byte{0x01, 0x03, 0xc0, 0xa8, 0x01, 0x01, 0x00, 0x00},
+		},
+		{
+			name:  "ospf route type is not ospf domain id",
+			input: []byte{0x03, 0x06, 0x00, 0x00, 0x00, 0x00, 0x05, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			got, ok := ext.GetOSPFDomainID()
+			if ok != tt.ok || got != tt.expect {
+				t.Errorf("expected ospf domain id %q %t, got %q %t", tt.expect, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestExtCommunityTrafficMarking(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect uint8
+		ok     bool
+		str    string
+	}{
+		{
+			name:   "remark to ef",
+			input:  []byte{0x80, 0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x2e},
+			expect: 46,
+			ok:     true,
+			str:    "flowspec-traffic-remarking=[ 0x00, 0x00, 0x00, 0x00, 0x00, 0x2E ]",
+		},
+		{
+			name:   "reserved bits are ignored",
+			input:  []byte{0x80, 0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0},
+			expect: 0,
+			ok:     true,
+			str:    "flowspec-traffic-remarking=[ 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0 ]",
+		},
+		{
+			name:  "rate is not traffic marking",
+			input: []byte{0x80, 0x06, 0x00, 0x00, 0x46, 0x43, 0x50, 0x00},
+			str:   "flowspec-traffic-rate=AS: 0 Rate: 100000 bps",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			got, ok := ext.GetTrafficMarkingDSCP()
+			if ok != tt.ok || got != tt.expect {
+				t.Errorf("expected dscp %d %t, got %d %t", tt.expect, tt.ok, got, ok)
+			}
+			if s := ext.String(); s != tt.str {
+				t.Errorf("expected %q, got %q", tt.str, s)
+			}
+		})
+	}
+}
+
+func TestExtCommunityColorCO(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		color uint32
+		co    ColorCO
+		ok    bool
+	}{
+		{
+			name:  "co 00 specific endpoint",
+			input: []byte{0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64},
+			color: 100,
+			co:    ColorCOSpecificEndpoint,
+			ok:    true,
+		},
+		{
+			name:  "co 01 null endpoint",
+			input: []byte{0x03, 0x0b, 0x40, 0x00, 0x00, 0x00, 0x00, 0x64},
+			color: 100,
+			co:    ColorCONullEndpoint,
+			ok:    true,
+		},
+		{
+			name:  "co 10 any endpoint",
+			input: []byte{0x03, 0x0b, 0x80, 0x00, 0x00, 0x00, 0x00, 0xc8},
+			color: 200,
+			co:    ColorCOAnyEndpoint,
+			ok:    true,
+		},
+		{
+			name:  "co 11 reserved",
+			input: []byte{0x03, 0x0b, 0xc0, 0x00, 0x00, 0x00, 0x00, 0xc8},
+			color: 200,
+			co:    ColorCOReserved,
+			ok:    true,
+		},
+		{
+			name:  "encapsulation is not color",
+			input: []byte{0x03, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			color, ok := ext.GetColor()
+			if ok != tt.ok || color != tt.color {
+				t.Errorf("expected color %d %t, got %d %t", tt.color, tt.ok, color, ok)
+			}
+			co, ok := ext.GetColorCO()
+			if ok != tt.ok || co != tt.co {
+				t.Errorf("expected co %s %t, got %s %t", tt.co, tt.ok, co, ok)
+			}
+		})
+	}
+}
+
+func TestExtCommunityConsistentHashSortOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		order  uint32
+		ok     bool
+		expect string
+	}{
+		{
+			name:   "consistent hash sort order",
+			input:  []byte{0x03, 0x14, 0x00, 0x00, 0x01, 0x2c, 0x00, 0x00},
+			order:  300,
+			ok:     true,
+			expect: "chso=300",
+		},
+		{
+			name:   "color is not consistent hash sort order",
+			input:  []byte{0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64},
+			expect: "color=100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			order, ok := ext.GetConsistentHashSortOrder()
+			if ok != tt.ok || order != tt.order {
+				t.Errorf("expected order %d %t, got %d %t", tt.order, tt.ok, order, ok)
+			}
+			if s := ext.String(); s != tt.expect {
+				t.Errorf("expected %s, got %s", tt.expect, s)
+			}
+		})
+	}
+}
+
+func TestExtCommunityEVPNL2Attributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		l2     *EVPNL2Attributes
+		ok     bool
+		expect string
+	}{
+		{
+			name:   "control word and primary",
+			input:  []byte{0x06, 0x04, 0x00, 0x06, 0x05, 0xdc, 0x00, 0x00},
+			l2:     &EVPNL2Attributes{Primary: true, ControlWord: true, MTU: 1500},
+			ok:     true,
+			expect: "l2attr=0x0006:1500",
+		},
+		{
+			name:   "backup without mtu",
+			input:  []byte{0x06, 0x04, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00},
+			l2:     &EVPNL2Attributes{Backup: true},
+			ok:     true,
+			expect: "l2attr=0x0001:0",
+		},
+		{
+			name:   "router's mac is not layer 2 attributes",
+			input:  []byte{0x06, 0x03, 0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+			expect: "rmac=00:11:22:33:44:55",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ext, err := makeExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("with error: %+v", err)
+			}
+			l2, ok := ext.GetEVPNL2Attributes()
+			if ok != tt.ok || !reflect.DeepEqual(l2, tt.l2) {
+				t.Errorf("expected layer 2 attributes %+v %t, got %+v %t", tt.l2, tt.ok, l2, ok)
+			}
+			if s := ext.String(); s != tt.expect {
+				t.Errorf("expected %s, got %s", tt.expect, s)
+			}
+		})
+	}
+}
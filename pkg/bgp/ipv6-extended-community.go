@@ -0,0 +1,99 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/tools"
+)
+
+// IPv6ExtCommunity defines IPv6 Address Specific Extended Community carried by attribute 25,
+// https://www.rfc-editor.org/rfc/rfc5701
+type IPv6ExtCommunity struct {
+	Type                uint8
+	SubType             uint8
+	GlobalAdministrator net.IP
+	LocalAdministrator  uint16
+}
+
+// Transitive IPv6-Address-Specific Extended Community Sub-Types
+// 0x02	Route Target	[RFC5701]
+// 0x03	Route Origin	[RFC5701]
+// 0x0b	VRF Route Import	[RFC6515]
+// 0x0c	Flow-spec Redirect to IPv6	[draft-ietf-idr-flowspec-redirect-ip]
+// 0x0d	Flow-spec Redirect to VRF, rt-redirect-ipv6	[RFC8956]
+// 0x10	Cisco VPN-Distinguisher	[Eric_Rosen]
+// 0x12	Inter-Area P2MP Segmented Next-Hop	[RFC7524]
+var transIPv6SubTypes = map[uint8]string{
+	0x2:  ECPRouteTarget,
+	0x3:  ECPRouteOrigin,
+	0x0b: ECPVRFRouteImport,
+	0x0c: ECPFlowSpecRedirIPv6,
+	0x0d: CPFlowspecRedirect,
+	0x10: ECPCiscoVPNDistinguisher,
+	0x12: ECPInterAreaP2MPSegmentedNexyHop,
+}
+
+func (ext *IPv6ExtCommunity) String() string {
+	value := fmt.Sprintf("[%s]:%d", ext.GlobalAdministrator.String(), ext.LocalAdministrator)
+	if ext.Type == 0x00 {
+		if prefix, ok := transIPv6SubTypes[ext.SubType]; ok {
+			return prefix + value
+		}
+	}
+
+	return fmt.Sprintf("unknown=Type: %d Subtype: %d Value: %s", ext.Type, ext.SubType, value)
+}
+
+// IsRouteTarget returns true if IPv6 Address Specific Extended Community is Route Target
+func (ext *IPv6ExtCommunity) IsRouteTarget() bool {
+	return ext.Type == 0x00 && ext.SubType == 0x02
+}
+
+// GetFlowspecRedirect returns the route target of Flowspec redirect to VRF action carried by IPv6 Address
+// Specific Extended Community of sub type 0x0d and true, the traffic is redirected to the VRF importing
+// the route target, RFC 8956 section 6.1. For any other extended community false is returned.
+func (ext *IPv6ExtCommunity) GetFlowspecRedirect() (string, bool) {
+	if ext.Type != 0x00 || ext.SubType != 0x0d {
+		return "", false
+	}
+
+	return fmt.Sprintf("[%s]:%d", ext.GlobalAdministrator.String(), ext.LocalAdministrator), true
+}
+
+func makeIPv6ExtCommunity(b []byte) (*IPv6ExtCommunity, error) {
+	if len(b) != 20 {
+		return nil, fmt.Errorf("invalid length expected 20 got %d", len(b))
+	}
+	ext := &IPv6ExtCommunity{
+		Type:                b[0],
+		SubType:             b[1],
+		GlobalAdministrator: make(net.IP, 16),
+		LocalAdministrator:  binary.BigEndian.Uint16(b[18:20]),
+	}
+	copy(ext.GlobalAdministrator, b[2:18])
+
+	return ext, nil
+}
+
+// UnmarshalBGPIPv6ExtCommunity builds a slice of IPv6 Address Specific Extended Communities
+func UnmarshalBGPIPv6ExtCommunity(b []byte) ([]IPv6ExtCommunity, error) {
+	if glog.V(6) {
+		glog.Infof("IPv6 Address Specific Extended communities: %s", tools.MessageHex(b))
+	}
+	if len(b)%20 != 0 {
+		return nil, fmt.Errorf("invalid length of ipv6 address specific extended community attribute %d", len(b))
+	}
+	exts := make([]IPv6ExtCommunity, 0)
+	for p := 0; p < len(b); p += 20 {
+		ext, err := makeIPv6ExtCommunity(b[p : p+20])
+		if err != nil {
+			return nil, err
+		}
+		exts = append(exts, *ext)
+	}
+
+	return exts, nil
+}
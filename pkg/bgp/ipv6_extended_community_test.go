@@ -0,0 +1,64 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIPv6ExtCommunity(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expect   string
+		redirect string
+		ok       bool
+	}{
+		{
+			name:     "flowspec redirect to vrf",
+			input:    []byte{0x00, 0x0d, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x64},
+			expect:   "flowspec-redirect=[2001:db8::1]:100",
+			redirect: "[2001:db8::1]:100",
+			ok:       true,
+		},
+		{
+			name:   "route target",
+			input:  []byte{0x00, 0x02, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x64},
+			expect: "rt=[2001:db8::1]:100",
+		},
+		{
+			name:   "vrf route import is not redirect",
+			input:  []byte{0x00, 0x0b, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x64},
+			expect: "vri=[2001:db8::1]:100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exts, err := UnmarshalBGPIPv6ExtCommunity(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			if len(exts) != 1 {
+				t.Fatalf("expected 1 extended community, got %d", len(exts))
+			}
+			if s := exts[0].String(); s != tt.expect {
+				t.Errorf("expected %s, got %s", tt.expect, s)
+			}
+			redirect, ok := exts[0].GetFlowspecRedirect()
+			if ok != tt.ok || redirect != tt.redirect {
+				t.Errorf("expected redirect %q %t, got %q %t", tt.redirect, tt.ok, redirect, ok)
+			}
+		})
+	}
+}
+
+func TestIPv6ExtCommunityBaseAttributes(t *testing.T) {
+	input := []byte{0xc0, 0x19, 0x14, 0x00, 0x0d, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x64}
+	attrs, err := UnmarshalBGPBaseAttributes(input)
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	expect := []string{"flowspec-redirect=[2001:db8::1]:100"}
+	if !reflect.DeepEqual(expect, attrs.IPv6ExtCommunityList) {
+		t.Fatalf("expected ipv6 extended communities %v, got %v", expect, attrs.IPv6ExtCommunityList)
+	}
+}
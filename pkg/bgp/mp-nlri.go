@@ -1,6 +1,9 @@
 package bgp
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/sbezverk/gobmp/pkg/base"
 	"github.com/sbezverk/gobmp/pkg/evpn"
 	"github.com/sbezverk/gobmp/pkg/flowspec"
@@ -8,6 +11,31 @@ import (
 	"github.com/sbezverk/gobmp/pkg/srpolicy"
 )
 
+// ErrNLRITypeMismatch is returned wrapped by MPNLRI accessors when AFI/SAFI of MP_REACH_NLRI or MP_UNREACH_NLRI
+// does not match the requested NLRI type, errors unmarshaling NLRI of matching type are returned as they are.
+var ErrNLRITypeMismatch = errors.New("nlri type not found")
+
+func nlriTypeMismatch(afi uint16, safi uint8) error {
+	return fmt.Errorf("%w in afi %d safi %d", ErrNLRITypeMismatch, afi, safi)
+}
+
+// unmarshalFlowspecNLRI selects Flowspec NLRI decoder by AFI and SAFI, IPv4 and IPv6 Flowspec differ in encoding
+// of Prefix components and VPN Flowspec carries Route Distinguisher in front of the components.
+func unmarshalFlowspecNLRI(afi uint16, safi uint8, b []byte) (*flowspec.NLRI, error) {
+	switch {
+	case afi == 1 && safi == 133:
+		return flowspec.UnmarshalFlowspecNLRI(b)
+	case afi == 1 && safi == 134:
+		return flowspec.UnmarshalVPNFlowspecNLRI(b)
+	case afi == 2 && safi == 133:
+		return flowspec.UnmarshalIPv6FlowspecNLRI(b)
+	case afi == 2 && safi == 134:
+		return flowspec.UnmarshalIPv6VPNFlowspecNLRI(b)
+	}
+
+	return nil, nlriTypeMismatch(afi, safi)
+}
+
 // MPNLRI defines a common interface methind for MP Reach and MP Unreach NLRIs
 type MPNLRI interface {
 	GetAFISAFIType() int
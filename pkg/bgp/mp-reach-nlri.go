@@ -26,8 +26,12 @@ type MPReachNLRI struct {
 	NLRI                 []byte
 	// When BGP update carries Prefix SID attribute 40, the processing of some AFI/SAFI NLRIs
 	// may differ from the standard processing.
-	SRv6    bool
-	addPath map[int]bool
+	SRv6 bool
+	// NLRICount is the number of NLRIs carried in NLRI field, only well formed NLRIs are counted
+	NLRICount int
+	// NLRILength is the length in bytes of NLRI field
+	NLRILength int
+	addPath    map[int]bool
 }
 
 // GetAFISAFIType returns underlaying NLRI's type based on AFI/SAFI
@@ -97,8 +101,7 @@ func (mp *MPReachNLRI) GetNLRI71() (*ls.NLRI71, error) {
 		return nlri71, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRI73 check for presense of NLRI 73 in the NLRI 14 NLRI data and if exists, instantiate NLRI73 object
@@ -111,11 +114,10 @@ func (mp *MPReachNLRI) GetNLRI73() (*srpolicy.NLRI73, error) {
 		return nlri73, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
-// GetNLRIL3VPN check for presense of NLRI L3VPN AFI 1 and SAFI 128 in the NLRI 14 NLRI data and if exists, instantiate L3VPN object
+// GetNLRIL3VPN check for presense of NLRI L3VPN AFI 1 or 2 and SAFI 128 in the NLRI 14 NLRI data and if exists, instantiate L3VPN object
 func (mp *MPReachNLRI) GetNLRIL3VPN() (*base.MPNLRI, error) {
 	if (mp.AddressFamilyID == 1 || mp.AddressFamilyID == 2) && mp.SubAddressFamilyID == 128 {
 		pathID := mp.addPath[NLRIMessageType(mp.AddressFamilyID, mp.SubAddressFamilyID)]
@@ -126,8 +128,7 @@ func (mp *MPReachNLRI) GetNLRIL3VPN() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRIEVPN check for presense of NLRI EVPN AFI 25 and SAFI 70 in the NLRI 14 NLRI data and if exists, instantiate EVPN object
@@ -140,8 +141,7 @@ func (mp *MPReachNLRI) GetNLRIEVPN() (*evpn.Route, error) {
 		return route, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRIUnicast check for presense of NLRI EVPN AFI 1 or 2  and SAFI 1 in the NLRI 14 NLRI data and if exists, instantiate Unicast object
@@ -155,8 +155,7 @@ func (mp *MPReachNLRI) GetNLRIUnicast() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRILU check for presense of NLRI EVPN AFI 1 or 2  and SAFI 4 in the NLRI 14 NLRI data and if exists, instantiate Unicast object
@@ -170,18 +169,13 @@ func (mp *MPReachNLRI) GetNLRILU() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
-// GetFlowspecNLRI checks for presense of NLRI 133 IPv4 Flowspec in the NLRI 14 NLRI data and if exists, instantiate NLRI object
+// GetFlowspecNLRI checks for presense of NLRI 133 Flowspec or NLRI 134 VPN Flowspec of AFI 1 or 2 in the NLRI 14 NLRI data
+// and if exists, instantiate NLRI object
 func (mp *MPReachNLRI) GetFlowspecNLRI() (*flowspec.NLRI, error) {
-	if mp.SubAddressFamilyID == 133 {
-		return flowspec.UnmarshalFlowspecNLRI(mp.NLRI)
-	}
-
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return unmarshalFlowspecNLRI(mp.AddressFamilyID, mp.SubAddressFamilyID, mp.NLRI)
 }
 
 // UnmarshalMPReachNLRI builds MP Reach NLRI attributes
@@ -210,6 +204,58 @@ func UnmarshalMPReachNLRI(b []byte, srv6 bool, addPath map[int]bool) (MPNLRI, er
 	p++
 	mp.NLRI = make([]byte, len(b[p:]))
 	copy(mp.NLRI, b[p:])
+	mp.NLRILength = len(mp.NLRI)
+	mp.NLRICount = countNLRI(mp.SubAddressFamilyID, mp.addPath[mp.GetAFISAFIType()], mp.NLRI)
 
 	return &mp, nil
 }
+
+// countNLRI returns the number of NLRIs found in b by walking NLRI length fields, encoding of the length
+// depends on SAFI, when add path is enabled each NLRI is preceded by 4 bytes Path ID. Walking stops
+// at the first NLRI exceeding b.
+func countNLRI(safi uint8, pathID bool, b []byte) int {
+	n := 0
+	for p := 0; p < len(b); n++ {
+		if pathID {
+			p += 4
+		}
+		switch safi {
+		case 5, 70:
+			// MCAST-VPN and EVPN NLRI, 1 byte of Route Type followed by 1 byte of length
+			if p+2 > len(b) {
+				return n
+			}
+			p += 2 + int(b[p+1])
+		case 71, 72:
+			// BGP-LS NLRI, 2 bytes of NLRI Type followed by 2 bytes of length
+			if p+4 > len(b) {
+				return n
+			}
+			p += 4 + int(binary.BigEndian.Uint16(b[p+2:p+4]))
+		case 133, 134:
+			// Flowspec NLRI length of 240 bytes or more is encoded into 2 bytes as 0xfnnn
+			if p+1 > len(b) {
+				return n
+			}
+			if b[p]&0xf0 == 0xf0 {
+				if p+2 > len(b) {
+					return n
+				}
+				p += 2 + int(binary.BigEndian.Uint16(b[p:p+2])&0x0fff)
+			} else {
+				p += 1 + int(b[p])
+			}
+		default:
+			// Prefix based NLRI, 1 byte of length in bits
+			if p+1 > len(b) {
+				return n
+			}
+			p += 1 + (int(b[p])+7)/8
+		}
+		if p > len(b) {
+			return n
+		}
+	}
+
+	return n
+}
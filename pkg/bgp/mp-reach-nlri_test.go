@@ -24,6 +24,8 @@ func TestUnmarshalMPReachNLRI(t *testing.T) {
 				NextHopAddressLength: 16,
 				NextHopAddress:       []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x0A, 0x98, 0xB7, 0x0B},
 				NLRI:                 []byte{0x10, 0x20, 0x01},
+				NLRICount:            1,
+				NLRILength:           3,
 				addPath:              map[int]bool{},
 			},
 			srv6:    false,
@@ -38,11 +40,37 @@ func TestUnmarshalMPReachNLRI(t *testing.T) {
 				NextHopAddressLength: 8,
 				NextHopAddress:       []byte{0, 0, 0, 0, 0, 0, 0, 0},
 				NLRI:                 []byte{0x78, 0x13, 0x88, 0x11, 0x00, 0x01, 0x01, 0x01, 0x0A, 0x01, 0x00, 0x01, 0x0B, 0x0B, 0x0B, 0x0B, 0x70, 0x13, 0x88, 0x11, 0x00, 0x01, 0x01, 0x01, 0x0A, 0x01, 0x00, 0x01, 0x01, 0x64, 0x01},
+				NLRICount:            2,
+				NLRILength:           31,
 				addPath:              map[int]bool{},
 			},
 			srv6:    false,
 			addPath: map[int]bool{},
 		},
+		{
+			name: "ipv4 unicast three prefixes with add path",
+			input: []byte{
+				0x00, 0x01, 0x01, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x00,
+				0x00, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+				0x00, 0x00, 0x00, 0x02, 0x10, 0xac, 0x10,
+				0x00, 0x00, 0x00, 0x03, 0x20, 0xc0, 0xa8, 0x01, 0x01,
+			},
+			expect: &MPReachNLRI{
+				AddressFamilyID:      1,
+				SubAddressFamilyID:   1,
+				NextHopAddressLength: 4,
+				NextHopAddress:       []byte{0x0a, 0x00, 0x00, 0x01},
+				NLRI: []byte{
+					0x00, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+					0x00, 0x00, 0x00, 0x02, 0x10, 0xac, 0x10,
+					0x00, 0x00, 0x00, 0x03, 0x20, 0xc0, 0xa8, 0x01, 0x01,
+				},
+				NLRICount:  3,
+				NLRILength: 24,
+				addPath:    map[int]bool{1: true},
+			},
+			addPath: map[int]bool{1: true},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -57,3 +85,51 @@ func TestUnmarshalMPReachNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestCountNLRI(t *testing.T) {
+	tests := []struct {
+		name   string
+		safi   uint8
+		pathID bool
+		input  []byte
+		expect int
+	}{
+		{
+			name:   "evpn two routes",
+			safi:   70,
+			input:  []byte{0x01, 0x02, 0xaa, 0xbb, 0x03, 0x01, 0xcc},
+			expect: 2,
+		},
+		{
+			name:   "ls one nlri",
+			safi:   71,
+			input:  []byte{0x00, 0x01, 0x00, 0x02, 0xaa, 0xbb},
+			expect: 1,
+		},
+		{
+			name:   "flowspec two nlri",
+			safi:   133,
+			input:  []byte{0x05, 0x01, 0x18, 0x0a, 0x0a, 0x0a, 0x03, 0x03, 0x81, 0x06},
+			expect: 2,
+		},
+		{
+			name:   "truncated last prefix is not counted",
+			safi:   1,
+			input:  []byte{0x18, 0x0a, 0x0a, 0x0a, 0x18, 0x0a, 0x0a},
+			expect: 1,
+		},
+		{
+			name:   "empty nlri",
+			safi:   1,
+			input:  []byte{},
+			expect: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countNLRI(tt.safi, tt.pathID, tt.input); got != tt.expect {
+				t.Fatalf("expected %d nlri, got %d", tt.expect, got)
+			}
+		})
+	}
+}
@@ -20,7 +20,10 @@ type MPUnReachNLRI struct {
 	AddressFamilyID    uint16
 	SubAddressFamilyID uint8
 	WithdrawnRoutes    []byte
-	addPath            map[int]bool
+	// EndOfRIB is true when MP_UNREACH_NLRI carries only AFI and SAFI, which is End-of-RIB marker
+	// of the address family, RFC 4724 section 2.
+	EndOfRIB bool
+	addPath  map[int]bool
 }
 
 // GetAFISAFIType returns underlaying NLRI's type based on AFI/SAFI
@@ -54,8 +57,7 @@ func (mp *MPUnReachNLRI) GetNLRI71() (*ls.NLRI71, error) {
 		return nlri71, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRI73 check for presense of NLRI 73 in the NLRI 14 NLRI data and if exists, instantiate NLRI73 object
@@ -68,13 +70,12 @@ func (mp *MPUnReachNLRI) GetNLRI73() (*srpolicy.NLRI73, error) {
 		return nlri73, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
-// GetNLRIL3VPN check for presense of NLRI L3VPN AFI 1 and SAFI 128 in the NLRI 14 NLRI data and if exists, instantiate L3VPN object
+// GetNLRIL3VPN check for presense of NLRI L3VPN AFI 1 or 2 and SAFI 128 in the NLRI 15 NLRI data and if exists, instantiate L3VPN object
 func (mp *MPUnReachNLRI) GetNLRIL3VPN() (*base.MPNLRI, error) {
-	if mp.AddressFamilyID == 1 && mp.SubAddressFamilyID == 128 {
+	if (mp.AddressFamilyID == 1 || mp.AddressFamilyID == 2) && mp.SubAddressFamilyID == 128 {
 		pathID := mp.addPath[NLRIMessageType(mp.AddressFamilyID, mp.SubAddressFamilyID)]
 		nlri, err := l3vpn.UnmarshalL3VPNNLRI(mp.WithdrawnRoutes, pathID)
 		if err != nil {
@@ -83,8 +84,7 @@ func (mp *MPUnReachNLRI) GetNLRIL3VPN() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRIEVPN check for presense of NLRI EVPN AFI 25 and SAFI 70 in the NLRI 14 NLRI data and if exists, instantiate EVPN object
@@ -97,8 +97,7 @@ func (mp *MPUnReachNLRI) GetNLRIEVPN() (*evpn.Route, error) {
 		return route, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRIUnicast check for presense of NLRI EVPN AFI 1 or 2  and SAFI 1 in the NLRI 14 NLRI data and if exists, instantiate Unicast object
@@ -112,8 +111,7 @@ func (mp *MPUnReachNLRI) GetNLRIUnicast() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
 // GetNLRILU check for presense of NLRI EVPN AFI 1 or 2  and SAFI 4 in the NLRI 14 NLRI data and if exists, instantiate Unicast object
@@ -127,18 +125,17 @@ func (mp *MPUnReachNLRI) GetNLRILU() (*base.MPNLRI, error) {
 		return nlri, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return nil, nlriTypeMismatch(mp.AddressFamilyID, mp.SubAddressFamilyID)
 }
 
-// GetFlowspecNLRI checks for presense of NLRI 133 IPv4 Flowspec in the NLRI 15 NLRI data and if exists, instantiate NLRI object
+// GetFlowspecNLRI checks for presense of NLRI 133 Flowspec or NLRI 134 VPN Flowspec of AFI 1 or 2 in the NLRI 15 NLRI data
+// and if exists, instantiate NLRI object, for End-of-RIB marker an empty NLRI object is returned.
 func (mp *MPUnReachNLRI) GetFlowspecNLRI() (*flowspec.NLRI, error) {
-	if mp.SubAddressFamilyID == 133 {
-		return flowspec.UnmarshalFlowspecNLRI(mp.WithdrawnRoutes)
+	if mp.EndOfRIB && mp.GetAFISAFIType() == 27 {
+		return &flowspec.NLRI{}, nil
 	}
 
-	// TODO return new type of errors to be able to check for the code
-	return nil, fmt.Errorf("not found")
+	return unmarshalFlowspecNLRI(mp.AddressFamilyID, mp.SubAddressFamilyID, mp.WithdrawnRoutes)
 }
 
 // UnmarshalMPUnReachNLRI builds MP Reach NLRI attributes
@@ -159,6 +156,28 @@ func UnmarshalMPUnReachNLRI(b []byte, addPath map[int]bool) (MPNLRI, error) {
 	p++
 	mp.WithdrawnRoutes = make([]byte, len(b[p:]))
 	copy(mp.WithdrawnRoutes, b[p:])
+	mp.EndOfRIB = len(mp.WithdrawnRoutes) == 0
 
 	return &mp, nil
 }
+
+// Marshal builds wire format of MP_UNREACH_NLRI attribute's value, when EndOfRIB is true,
+// only AFI and SAFI are written and WithdrawnRoutes are ignored.
+func (mp *MPUnReachNLRI) Marshal() ([]byte, error) {
+	l := 3
+	if !mp.EndOfRIB {
+		l += len(mp.WithdrawnRoutes)
+	}
+	if l > 0xffff {
+		// Value must fit into Extended Length attribute
+		return nil, fmt.Errorf("withdrawn routes of %d bytes exceed maximum attribute length", len(mp.WithdrawnRoutes))
+	}
+	b := make([]byte, l)
+	binary.BigEndian.PutUint16(b[0:2], mp.AddressFamilyID)
+	b[2] = mp.SubAddressFamilyID
+	if !mp.EndOfRIB {
+		copy(b[3:], mp.WithdrawnRoutes)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,299 @@
+package bgp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/base"
+	"github.com/sbezverk/gobmp/pkg/flowspec"
+)
+
+func TestMPUnReachNLRIAddPathWithdraw(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		addPath map[int]bool
+		expect  []base.Route
+	}{
+		{
+			name: "ipv4 unicast two path ids of one prefix",
+			input: []byte{
+				0x00, 0x01, 0x01,
+				0x00, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+				0x00, 0x00, 0x00, 0x02, 0x18, 0x0a, 0x0a, 0x0a,
+			},
+			addPath: map[int]bool{1: true},
+			expect: []base.Route{
+				{PathID: 1, Length: 24, Prefix: []byte{0x0a, 0x0a, 0x0a}},
+				{PathID: 2, Length: 24, Prefix: []byte{0x0a, 0x0a, 0x0a}},
+			},
+		},
+		{
+			name: "ipv6 unicast two path ids of one prefix",
+			input: []byte{
+				0x00, 0x02, 0x01,
+				0x00, 0x00, 0x00, 0x05, 0x20, 0x20, 0x01, 0x0d, 0xb8,
+				0x00, 0x00, 0x00, 0x07, 0x20, 0x20, 0x01, 0x0d, 0xb8,
+			},
+			addPath: map[int]bool{2: true},
+			expect: []base.Route{
+				{PathID: 5, Length: 32, Prefix: []byte{0x20, 0x01, 0x0d, 0xb8}},
+				{PathID: 7, Length: 32, Prefix: []byte{0x20, 0x01, 0x0d, 0xb8}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp, err := UnmarshalMPUnReachNLRI(tt.input, tt.addPath)
+			if err != nil {
+				t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+			}
+			nlri, err := mp.GetNLRIUnicast()
+			if err != nil {
+				t.Fatalf("failed to get unicast nlri with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, nlri.NLRI) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, nlri.NLRI))
+				t.Fatal("the expected withdrawn routes do not match the actual")
+			}
+			events, err := mpRIBEvents(mp, true)
+			if err != nil {
+				t.Fatalf("failed to get rib events with error: %+v", err)
+			}
+			groups := GroupByPrefix(events)
+			if len(groups) != 1 {
+				t.Fatalf("expected a single prefix, got %d", len(groups))
+			}
+			for _, g := range groups {
+				if len(g) != len(tt.expect) {
+					t.Fatalf("expected %d withdrawals of the prefix, got %d", len(tt.expect), len(g))
+				}
+				for i, e := range g {
+					if !e.Withdraw || e.PathID != tt.expect[i].PathID {
+						t.Errorf("expected withdrawal of path id %d, got %+v", tt.expect[i].PathID, e)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMPUnReachNLRITypeMismatch(t *testing.T) {
+	// IPv4 unicast withdrawal of 10.10.10.0/24
+	mp, err := UnmarshalMPUnReachNLRI([]byte{0x00, 0x01, 0x01, 0x18, 0x0a, 0x0a, 0x0a}, nil)
+	if err != nil {
+		t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+	}
+	accessors := map[string]func() error{
+		"nlri 71":  func() error { _, err := mp.GetNLRI71(); return err },
+		"nlri 73":  func() error { _, err := mp.GetNLRI73(); return err },
+		"l3vpn":    func() error { _, err := mp.GetNLRIL3VPN(); return err },
+		"evpn":     func() error { _, err := mp.GetNLRIEVPN(); return err },
+		"lu":       func() error { _, err := mp.GetNLRILU(); return err },
+		"flowspec": func() error { _, err := mp.GetFlowspecNLRI(); return err },
+	}
+	for name, f := range accessors {
+		if err := f(); !errors.Is(err, ErrNLRITypeMismatch) {
+			t.Errorf("%s: expected ErrNLRITypeMismatch but got: %+v", name, err)
+		}
+	}
+	if _, err := mp.GetNLRIUnicast(); err != nil {
+		t.Fatalf("failed to get unicast nlri with error: %+v", err)
+	}
+	// Malformed IPv4 unicast withdrawal with truncated prefix
+	mp, err = UnmarshalMPUnReachNLRI([]byte{0x00, 0x01, 0x01, 0x18, 0x0a, 0x0a}, nil)
+	if err != nil {
+		t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+	}
+	if _, err := mp.GetNLRIUnicast(); err == nil || errors.Is(err, ErrNLRITypeMismatch) {
+		t.Fatalf("expected unmarshal error but got: %+v", err)
+	}
+}
+
+func TestMPUnReachNLRIVPNv6(t *testing.T) {
+	// VPNv6 withdrawal of 2001:db8:1:2::/64 with RD 65000:100 and Compatibility field instead of label
+	input := []byte{
+		0x00, 0x02, 0x80,
+		0x98, 0x80, 0x00, 0x00,
+		0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+		0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01, 0x00, 0x02,
+	}
+	mp, err := UnmarshalMPUnReachNLRI(input, nil)
+	if err != nil {
+		t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+	}
+	nlri, err := mp.GetNLRIL3VPN()
+	if err != nil {
+		t.Fatalf("failed to get l3vpn nlri with error: %+v", err)
+	}
+	expect := []base.Route{
+		{
+			Length: 64,
+			RD:     &base.RD{Type: 0, Value: []byte{0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64}},
+			Prefix: []byte{0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01, 0x00, 0x02},
+		},
+	}
+	if !reflect.DeepEqual(expect, nlri.NLRI) {
+		t.Logf("differences: %+v", deep.Equal(expect, nlri.NLRI))
+		t.Fatal("the expected withdrawn routes do not match the actual")
+	}
+	if rd := nlri.NLRI[0].RD.String(); rd != "65000:100" {
+		t.Fatalf("expected rd 65000:100, got %s", rd)
+	}
+}
+
+func TestMPUnReachNLRIMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		endOfRIB bool
+	}{
+		{
+			name: "ls node withdrawal",
+			input: []byte{
+				0x40, 0x04, 0x47,
+				0x00, 0x01, 0x00, 0x1f, 0x02,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x01, 0x00, 0x00, 0x16,
+				0x02, 0x00, 0x00, 0x04, 0x00, 0x00, 0xfd, 0xe8,
+				0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+			},
+		},
+		{
+			name: "l3vpn withdrawal",
+			input: []byte{
+				0x00, 0x01, 0x80,
+				0x70, 0x80, 0x00, 0x00,
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				0x0a, 0x0a, 0x0a,
+			},
+		},
+		{
+			name:     "end of rib",
+			input:    []byte{0x00, 0x02, 0x80},
+			endOfRIB: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalMPUnReachNLRI(tt.input, nil)
+			if err != nil {
+				t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+			}
+			mp := nlri.(*MPUnReachNLRI)
+			if mp.EndOfRIB != tt.endOfRIB {
+				t.Fatalf("expected end of rib %t, got %t", tt.endOfRIB, mp.EndOfRIB)
+			}
+			got, err := mp.Marshal()
+			if err != nil {
+				t.Fatalf("failed to marshal MP_UNREACH_NLRI with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.input, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.input, got))
+				t.Fatal("the marshaled MP_UNREACH_NLRI does not match the original")
+			}
+		})
+	}
+}
+
+func TestMPUnReachNLRIFlowspec(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *flowspec.NLRI
+		fail   bool
+	}{
+		{
+			name:  "ipv4 flowspec",
+			input: []byte{0x00, 0x01, 0x85, 0x05, 0x01, 0x18, 0x0a, 0x0a, 0x0a},
+			expect: &flowspec.NLRI{
+				AFI:    1,
+				SAFI:   133,
+				Length: 5,
+				Spec: []flowspec.Spec{
+					&flowspec.PrefixSpec{SpecType: 1, PrefixLength: 24, Prefix: []byte{0x0a, 0x0a, 0x0a}},
+				},
+			},
+		},
+		{
+			name: "vpnv4 flowspec",
+			input: []byte{
+				0x00, 0x01, 0x86, 0x0d,
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				0x01, 0x18, 0x0a, 0x0a, 0x0a,
+			},
+			expect: &flowspec.NLRI{
+				AFI:    1,
+				SAFI:   134,
+				Length: 13,
+				RD:     &base.RD{Type: 0, Value: []byte{0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64}},
+				Spec: []flowspec.Spec{
+					&flowspec.PrefixSpec{SpecType: 1, PrefixLength: 24, Prefix: []byte{0x0a, 0x0a, 0x0a}},
+				},
+			},
+		},
+		{
+			name:  "ipv6 flowspec with prefix offset",
+			input: []byte{0x00, 0x02, 0x85, 0x07, 0x01, 0x40, 0x20, 0x00, 0x01, 0x00, 0x02},
+			expect: &flowspec.NLRI{
+				AFI:    2,
+				SAFI:   133,
+				Length: 7,
+				Spec: []flowspec.Spec{
+					&flowspec.PrefixSpec{SpecType: 1, PrefixLength: 64, PrefixOffset: 32, Prefix: []byte{0x00, 0x01, 0x00, 0x02}},
+				},
+			},
+		},
+		{
+			name:   "end of rib",
+			input:  []byte{0x00, 0x01, 0x86},
+			expect: &flowspec.NLRI{},
+		},
+		{
+			name: "vpnv4 flowspec with malformed rd",
+			input: []byte{
+				0x00, 0x01, 0x86, 0x0d,
+				0x00, 0x05, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				0x01, 0x18, 0x0a, 0x0a, 0x0a,
+			},
+			fail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp, err := UnmarshalMPUnReachNLRI(tt.input, nil)
+			if err != nil {
+				t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+			}
+			got, err := mp.GetFlowspecNLRI()
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("failed to get flowspec nlri with error: %+v", err)
+				}
+				if errors.Is(err, ErrNLRITypeMismatch) {
+					t.Fatalf("expected unmarshal error but got: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("expected to fail but succeeded")
+			}
+			// Hash is validated by flowspec package tests
+			got.SpecHash = ""
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected flowspec nlri does not match the actual")
+			}
+		})
+	}
+	// Flowspec SAFI with unsupported address family
+	mp, err := UnmarshalMPUnReachNLRI([]byte{0x00, 0x19, 0x85, 0x05, 0x01, 0x18, 0x0a, 0x0a, 0x0a}, nil)
+	if err != nil {
+		t.Fatalf("failed to unmarshal MP_UNREACH_NLRI with error: %+v", err)
+	}
+	if _, err := mp.GetFlowspecNLRI(); !errors.Is(err, ErrNLRITypeMismatch) {
+		t.Fatalf("expected ErrNLRITypeMismatch but got: %+v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package bgp
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/gobmp/pkg/base"
+	"github.com/sbezverk/tools"
+)
+
+const (
+	// PMSI_TUNNEL defines BGP P-Multicast Service Interface Tunnel attribute type
+	PMSI_TUNNEL = 22
+
+	// PMSITunnelNoInfo defines PMSI Tunnel type "No tunnel information present"
+	PMSITunnelNoInfo = 0
+	// PMSITunnelRSVPTEP2MP defines PMSI Tunnel type RSVP-TE P2MP LSP
+	PMSITunnelRSVPTEP2MP = 1
+	// PMSITunnelMLDPP2MP defines PMSI Tunnel type mLDP P2MP LSP
+	PMSITunnelMLDPP2MP = 2
+	// PMSITunnelPIMSSM defines PMSI Tunnel type PIM-SSM Tree
+	PMSITunnelPIMSSM = 3
+	// PMSITunnelPIMSM defines PMSI Tunnel type PIM-SM Tree
+	PMSITunnelPIMSM = 4
+	// PMSITunnelBIDIRPIM defines PMSI Tunnel type BIDIR-PIM Tree
+	PMSITunnelBIDIRPIM = 5
+	// PMSITunnelIngressReplication defines PMSI Tunnel type Ingress Replication
+	PMSITunnelIngressReplication = 6
+	// PMSITunnelMLDPMP2MP defines PMSI Tunnel type mLDP MP2MP LSP
+	PMSITunnelMLDPMP2MP = 7
+)
+
+// PMSITunnel defines BGP PMSI Tunnel attribute (22) object
+// https://www.rfc-editor.org/rfc/rfc6514#section-5
+type PMSITunnel struct {
+	LeafInfoRequired bool   `json:"leaf_info_required"`
+	TunnelType       uint8  `json:"tunnel_type"`
+	Label            uint32 `json:"label"`
+	// RawLabel carries all 24 bits of MPLS Label field, for VXLAN the field carries VNI, RFC 8365
+	RawLabel uint32 `json:"raw_label"`
+	TunnelID []byte `json:"tunnel_id,omitempty"`
+	// TunnelEndpoint is set for Ingress Replication tunnel type and carries the IP address
+	// of the replication endpoint, for EVPN it is Type 3 route's Originating Router's IP address.
+	TunnelEndpoint string `json:"tunnel_endpoint,omitempty"`
+}
+
+// UnmarshalPMSITunnel builds PMSI Tunnel attribute object
+func UnmarshalPMSITunnel(b []byte) (*PMSITunnel, error) {
+	if glog.V(6) {
+		glog.Infof("PMSI Tunnel Raw: %s", tools.MessageHex(b))
+	}
+	// Flags 1 byte, Tunnel Type 1 byte, MPLS Label 3 bytes
+	if len(b) < 5 {
+		return nil, fmt.Errorf("invalid pmsi tunnel attribute length %d", len(b))
+	}
+	pt := &PMSITunnel{
+		LeafInfoRequired: b[0]&0x01 == 0x01,
+		TunnelType:       b[1],
+	}
+	l, err := base.MakeLabel(b[2:5])
+	if err != nil {
+		return nil, err
+	}
+	pt.Label = l.Value
+	pt.RawLabel = l.GetRawValue()
+	pt.TunnelID = make([]byte, len(b)-5)
+	copy(pt.TunnelID, b[5:])
+	if pt.TunnelType == PMSITunnelIngressReplication {
+		switch len(pt.TunnelID) {
+		case 4:
+			pt.TunnelEndpoint = net.IP(pt.TunnelID).To4().String()
+		case 16:
+			pt.TunnelEndpoint = net.IP(pt.TunnelID).To16().String()
+		default:
+			return nil, fmt.Errorf("invalid ingress replication tunnel endpoint length %d", len(pt.TunnelID))
+		}
+	}
+
+	return pt, nil
+}
@@ -0,0 +1,74 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sbezverk/gobmp/pkg/evpn"
+)
+
+func TestEVPNInclusiveMulticastPMSITunnel(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expect   *PMSITunnel
+		originIP []byte
+	}{
+		{
+			name: "type 3 route with ingress replication",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x32,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x00,
+				0x80, 0x0e, 0x1c, 0x00, 0x19, 0x46, 0x04, 0xac, 0x1f, 0x65, 0x06, 0x00,
+				0x03, 0x11, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x20, 0xac, 0x1f, 0x65, 0x06,
+				0xc0, 0x16, 0x09, 0x00, 0x06, 0x00, 0x27, 0x10, 0xac, 0x1f, 0x65, 0x06,
+			},
+			expect: &PMSITunnel{
+				TunnelType:     PMSITunnelIngressReplication,
+				Label:          625,
+				RawLabel:       10000,
+				TunnelID:       []byte{0xac, 0x1f, 0x65, 0x06},
+				TunnelEndpoint: "172.31.101.6",
+			},
+			originIP: []byte{0xac, 0x1f, 0x65, 0x06},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			nlri, i := u.GetNLRIType()
+			if nlri != MP_REACH_NLRI {
+				t.Fatal("Update carries unexpected type")
+			}
+			mp, err := UnmarshalMPReachNLRI(u.PathAttributes[i].Attribute, false, nil)
+			if err != nil {
+				t.Fatalf("failed to unmarshal MP_REACH_NLRI with error: %+v", err)
+			}
+			route, err := mp.GetNLRIEVPN()
+			if err != nil {
+				t.Fatalf("failed to get EVPN NLRI with error: %+v", err)
+			}
+			if len(route.Route) != 1 {
+				t.Fatalf("expected 1 route, got %d", len(route.Route))
+			}
+			imet, ok := route.Route[0].RouteTypeSpec.(*evpn.InclusiveMulticastEthTag)
+			if !ok {
+				t.Fatalf("expected Inclusive Multicast Ethernet Tag route, got %T", route.Route[0].RouteTypeSpec)
+			}
+			if !reflect.DeepEqual(tt.originIP, imet.IPAddr) {
+				t.Fatalf("expected originating router's ip %v, got %v", tt.originIP, imet.IPAddr)
+			}
+			pt, err := u.GetAttrPMSITunnel()
+			if err != nil {
+				t.Fatalf("failed to get PMSI Tunnel with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, pt) {
+				t.Fatalf("expected PMSI Tunnel %+v does not match actual %+v", tt.expect, pt)
+			}
+		})
+	}
+}
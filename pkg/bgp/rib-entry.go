@@ -0,0 +1,103 @@
+package bgp
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// PeerContext defines the information about the peer a BGP Update was received from, it is not known
+// to BGP Update and is set by the caller, for BMP from the Per-Peer Header of Route Monitoring message.
+type PeerContext struct {
+	PeerAddr netip.Addr
+	PeerAS   uint32
+	// PostPolicy is set when the update was monitored in Adj-RIB-In post-policy
+	PostPolicy bool
+	// IsEBGP is set when the peer is in a different AS than the monitored router
+	IsEBGP bool
+	// AddPath carries address families for which Add Path capability was negotiated with the peer,
+	// keyed the same way as addPath of GetRIBEvents.
+	AddPath map[int]bool
+}
+
+// RIBEntry defines a single route with its path attributes as it would be stored in a RIB, MED and
+// LocalPref are 0 when the update does not carry the attribute.
+type RIBEntry struct {
+	Prefix
+	PathID  uint32
+	Labels  []uint32
+	NextHop netip.Addr
+	// LinkLocalNextHop is set for IPv6 routes carrying both global and link-local next hops, RFC 2545
+	LinkLocalNextHop netip.Addr
+	Origin           string
+	ASPath           []uint32
+	Communities      []string
+	ExtCommunities   []string
+	LargeCommunities []string
+	MED              uint32
+	LocalPref        uint32
+	PeerAddr         netip.Addr
+	PeerAS           uint32
+	PostPolicy       bool
+	IsEBGP           bool
+}
+
+// GetRIBEntries returns a slice of RIB entries for routes announced by BGP Update, address families
+// are the ones supported by GetRIBEvents, withdrawn routes do not carry attributes and are not returned,
+// use GetRIBEvents to get them. peer can be nil if the peer is not known.
+func (up *Update) GetRIBEntries(peer *PeerContext) ([]RIBEntry, error) {
+	var addPath map[int]bool
+	if peer != nil {
+		addPath = peer.AddPath
+	}
+	events, err := up.GetRIBEvents(addPath)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]RIBEntry, 0, len(events))
+	for _, e := range events {
+		if e.Withdraw {
+			continue
+		}
+		entry := RIBEntry{
+			Prefix: e.Prefix,
+			PathID: e.PathID,
+			Labels: e.Labels,
+		}
+		entry.NextHop, entry.LinkLocalNextHop = parseNextHop(e.NextHop)
+		if ba := up.BaseAttributes; ba != nil {
+			entry.Origin = ba.Origin
+			entry.ASPath = ba.ASPath
+			entry.Communities = ba.CommunityList
+			entry.ExtCommunities = ba.ExtCommunityList
+			entry.LargeCommunities = ba.LgCommunityList
+			entry.MED = ba.MED
+			entry.LocalPref = ba.LocalPref
+		}
+		if peer != nil {
+			entry.PeerAddr = peer.PeerAddr
+			entry.PeerAS = peer.PeerAS
+			entry.PostPolicy = peer.PostPolicy
+			entry.IsEBGP = peer.IsEBGP
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseNextHop converts next hop in the format of RIBEvent NextHop into global and link-local addresses,
+// addresses which cannot be parsed are returned as zero netip.Addr.
+func parseNextHop(nh string) (netip.Addr, netip.Addr) {
+	var global, linkLocal netip.Addr
+	parts := strings.Split(nh, ",")
+	if addr, err := netip.ParseAddr(strings.TrimSpace(parts[0])); err == nil {
+		global = addr
+	}
+	if len(parts) > 1 {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(parts[1])); err == nil {
+			linkLocal = addr
+		}
+	}
+
+	return global, linkLocal
+}
@@ -0,0 +1,109 @@
+package bgp
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGetRIBEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		peer   *PeerContext
+		expect []RIBEntry
+	}{
+		{
+			// Withdrawn 192.0.0.0/8 does not produce an entry
+			name: "ipv4 unicast with full attribute bundle",
+			input: []byte{
+				0x00, 0x02, 0x08, 0xc0, 0x00, 0x47,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x0a, 0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xea,
+				0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x80, 0x04, 0x04, 0x00, 0x00, 0x00, 0x64,
+				0x40, 0x05, 0x04, 0x00, 0x00, 0x00, 0xc8,
+				0xc0, 0x08, 0x04, 0xfd, 0xe9, 0x00, 0x64,
+				0xc0, 0x10, 0x08, 0x00, 0x02, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x64,
+				0xc0, 0x20, 0x0c, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02,
+				0x18, 0x0a, 0x0a, 0x0a, 0x10, 0xac, 0x10,
+			},
+			peer: &PeerContext{
+				PeerAddr:   netip.MustParseAddr("192.0.2.1"),
+				PeerAS:     65001,
+				PostPolicy: true,
+				IsEBGP:     true,
+			},
+			expect: []RIBEntry{
+				{
+					Prefix:           Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop:          netip.MustParseAddr("10.0.0.1"),
+					Origin:           "igp",
+					ASPath:           []uint32{65001, 65002},
+					Communities:      []string{"65001:100"},
+					ExtCommunities:   []string{"rt=65001:100"},
+					LargeCommunities: []string{"65001:1:2"},
+					MED:              100,
+					LocalPref:        200,
+					PeerAddr:         netip.MustParseAddr("192.0.2.1"),
+					PeerAS:           65001,
+					PostPolicy:       true,
+					IsEBGP:           true,
+				},
+				{
+					Prefix:           Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("172.16.0.0/16")},
+					NextHop:          netip.MustParseAddr("10.0.0.1"),
+					Origin:           "igp",
+					ASPath:           []uint32{65001, 65002},
+					Communities:      []string{"65001:100"},
+					ExtCommunities:   []string{"rt=65001:100"},
+					LargeCommunities: []string{"65001:1:2"},
+					MED:              100,
+					LocalPref:        200,
+					PeerAddr:         netip.MustParseAddr("192.0.2.1"),
+					PeerAS:           65001,
+					PostPolicy:       true,
+					IsEBGP:           true,
+				},
+			},
+		},
+		{
+			name: "ipv6 unicast with global and link-local next hops",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x42,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x0a, 0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfd, 0xea,
+				0x80, 0x0e, 0x2e, 0x00, 0x02, 0x01, 0x20,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				0xfe, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				0x00,
+				0x40, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01, 0x00, 0x00,
+			},
+			expect: []RIBEntry{
+				{
+					Prefix:           Prefix{AFISAFI: AFISAFI{AFI: 2, SAFI: 1}, Prefix: netip.MustParsePrefix("2001:db8:1::/64")},
+					NextHop:          netip.MustParseAddr("2001:db8::1"),
+					LinkLocalNextHop: netip.MustParseAddr("fe80::1"),
+					Origin:           "igp",
+					ASPath:           []uint32{65001, 65002},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdateWithContext(tt.input, &SessionContext{AS4: true})
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			entries, err := u.GetRIBEntries(tt.peer)
+			if err != nil {
+				t.Fatalf("failed to get RIB entries with error: %+v", err)
+			}
+			if diffs := deep.Equal(tt.expect, entries); len(diffs) != 0 {
+				t.Fatalf("unexpected RIB entries: %+v", diffs)
+			}
+		})
+	}
+}
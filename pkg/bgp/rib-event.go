@@ -0,0 +1,239 @@
+package bgp
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/sbezverk/gobmp/pkg/base"
+)
+
+// AFISAFI defines a pair of Address Family Identifier and Subsequent Address Family Identifier
+type AFISAFI struct {
+	AFI  uint16
+	SAFI uint8
+}
+
+// Prefix defines a key which identifies a route's prefix within its address family,
+// for VPN address families the key also includes the route distinguisher.
+type Prefix struct {
+	AFISAFI
+	RD     string
+	Prefix netip.Prefix
+}
+
+// RIBEvent defines a single route announcement or withdrawal recovered from BGP Update
+type RIBEvent struct {
+	Prefix
+	Withdraw bool
+	PathID   uint32
+	Labels   []uint32
+	NextHop  string
+	// PostPolicy is set when the route was monitored in Adj-RIB-In post-policy, it is not known
+	// to BGP Update and is set by the caller from the BMP Per-Peer Header's L flag.
+	PostPolicy bool
+	// IsEBGP is set when the route was learned from a peer in a different AS, it is not known to BGP Update
+	// and is set by the caller from the session's local AS and the BMP Per-Peer Header's Peer AS.
+	IsEBGP bool
+}
+
+// IsNextHopSelf returns true when route's next hop is the address of the peer the route was received from,
+// meaning the peer applies next-hop-self policy, false means the peer propagated the next hop unchanged.
+// nextHop is in the format of RIBEvent NextHop, for IPv6 global and link-local next hops separated by comma,
+// either of them matching the peer address is considered next-hop-self. IPv4-mapped IPv6 addresses match
+// their IPv4 addresses, false is returned when either address cannot be parsed.
+func IsNextHopSelf(nextHop, peerAddr string) bool {
+	peer, err := netip.ParseAddr(strings.TrimSpace(peerAddr))
+	if err != nil {
+		return false
+	}
+	for _, nh := range strings.Split(nextHop, ",") {
+		addr, err := netip.ParseAddr(strings.TrimSpace(nh))
+		if err != nil {
+			continue
+		}
+		if addr.Unmap() == peer.Unmap() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GroupByPrefix groups RIB events by their prefix, events for the same prefix advertised
+// with different path ids, labels or next hops end up in the same group and can be used
+// to build ECMP/multipath structures. The order of events within a group is preserved.
+func GroupByPrefix(events []RIBEvent) map[Prefix][]RIBEvent {
+	m := make(map[Prefix][]RIBEvent)
+	for _, e := range events {
+		m[e.Prefix] = append(m[e.Prefix], e)
+	}
+
+	return m
+}
+
+// KafkaKey returns a stable partition key of RIB event, all announcements and withdrawals of the prefix,
+// regardless of path id, labels or next hop, produce the same key, which lets a Kafka producer keep them
+// on one partition for ordered processing. The key is hex encoded MD5 hash of "afi/safi/rd/prefix" string,
+// where rd is empty for not VPN address families and prefix is in canonical masked form.
+func KafkaKey(event RIBEvent) []byte {
+	k := fmt.Sprintf("%d/%d/%s/%s", event.AFI, event.SAFI, event.RD, event.Prefix.Prefix.Masked().String())
+	h := md5.Sum([]byte(k))
+
+	return []byte(hex.EncodeToString(h[:]))
+}
+
+// GetRIBEvents returns a slice of RIB events for Unicast, Labeled Unicast and L3VPN routes
+// found in the legacy NLRI, Withdrawn Routes, MP_REACH_NLRI and MP_UNREACH_NLRI of BGP Update,
+// for End-of-RIB marker an empty slice is returned, see IsEndOfRIB.
+func (up *Update) GetRIBEvents(addPath map[int]bool) ([]RIBEvent, error) {
+	events := make([]RIBEvent, 0)
+	pathID := addPath[NLRIMessageType(1, 1)]
+	ipv4Unicast := AFISAFI{AFI: 1, SAFI: 1}
+	if len(up.WithdrawnRoutes) != 0 {
+		routes, err := base.UnmarshalRoutes(up.WithdrawnRoutes, pathID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range routes {
+			e, err := makeRIBEvent(ipv4Unicast, r, true, "")
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, *e)
+		}
+	}
+	if len(up.NLRI) != 0 {
+		routes, err := base.UnmarshalRoutes(up.NLRI, pathID)
+		if err != nil {
+			return nil, err
+		}
+		nh := ""
+		if up.BaseAttributes != nil {
+			nh = up.BaseAttributes.Nexthop
+		}
+		for _, r := range routes {
+			e, err := makeRIBEvent(ipv4Unicast, r, false, nh)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, *e)
+		}
+	}
+	for _, attr := range up.PathAttributes {
+		var mp MPNLRI
+		var err error
+		withdraw := false
+		switch attr.AttributeType {
+		case MP_REACH_NLRI:
+			mp, err = UnmarshalMPReachNLRI(attr.Attribute, up.HasPrefixSID(), addPath)
+		case MP_UNREACH_NLRI:
+			// MP_UNREACH_NLRI without withdrawn routes is End-of-RIB marker and does not carry events
+			if len(attr.Attribute) == 3 {
+				continue
+			}
+			withdraw = true
+			mp, err = UnmarshalMPUnReachNLRI(attr.Attribute, addPath)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		e, err := mpRIBEvents(mp, withdraw)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e...)
+	}
+
+	return events, nil
+}
+
+func mpRIBEvents(mp MPNLRI, withdraw bool) ([]RIBEvent, error) {
+	var nlri *base.MPNLRI
+	var err error
+	var afiSafi AFISAFI
+	switch mp.GetAFISAFIType() {
+	case 1:
+		afiSafi = AFISAFI{AFI: 1, SAFI: 1}
+		nlri, err = mp.GetNLRIUnicast()
+	case 2:
+		afiSafi = AFISAFI{AFI: 2, SAFI: 1}
+		nlri, err = mp.GetNLRIUnicast()
+	case 16:
+		afiSafi = AFISAFI{AFI: 1, SAFI: 4}
+		nlri, err = mp.GetNLRILU()
+	case 17:
+		afiSafi = AFISAFI{AFI: 2, SAFI: 4}
+		nlri, err = mp.GetNLRILU()
+	case 18:
+		afiSafi = AFISAFI{AFI: 1, SAFI: 128}
+		nlri, err = mp.GetNLRIL3VPN()
+	case 19:
+		afiSafi = AFISAFI{AFI: 2, SAFI: 128}
+		nlri, err = mp.GetNLRIL3VPN()
+	default:
+		// Other address families do not carry IP prefixes
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	nh := ""
+	if !withdraw {
+		nh = mp.GetNextHop()
+	}
+	events := make([]RIBEvent, 0, len(nlri.NLRI))
+	for _, r := range nlri.NLRI {
+		e, err := makeRIBEvent(afiSafi, r, withdraw, nh)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *e)
+	}
+
+	return events, nil
+}
+
+func makeRIBEvent(afiSafi AFISAFI, r base.Route, withdraw bool, nh string) (*RIBEvent, error) {
+	prefix, err := routePrefix(afiSafi.AFI, r)
+	if err != nil {
+		return nil, err
+	}
+	e := &RIBEvent{
+		Prefix: Prefix{
+			AFISAFI: afiSafi,
+			Prefix:  prefix,
+		},
+		Withdraw: withdraw,
+		PathID:   r.PathID,
+		NextHop:  nh,
+	}
+	if r.RD != nil {
+		e.RD = r.RD.String()
+	}
+	for _, l := range r.Label {
+		e.Labels = append(e.Labels, l.Value)
+	}
+
+	return e, nil
+}
+
+// routePrefix converts NLRI route's prefix into netip.Prefix
+func routePrefix(afi uint16, r base.Route) (netip.Prefix, error) {
+	l := 4
+	if afi == 2 {
+		l = 16
+	}
+	if len(r.Prefix) > l || int(r.Length) > l*8 {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix length %d for afi %d", r.Length, afi)
+	}
+	b := make([]byte, l)
+	copy(b, r.Prefix)
+	addr, _ := netip.AddrFromSlice(b)
+
+	return addr.Prefix(int(r.Length))
+}
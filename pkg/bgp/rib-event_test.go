@@ -0,0 +1,141 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGroupByPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		addPath map[int]bool
+		expect  map[Prefix][]RIBEvent
+	}{
+		{
+			name: "labeled unicast three path ids",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x2d,
+				0x80, 0x0e, 0x2a, 0x00, 0x01, 0x04, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x00,
+				0x00, 0x00, 0x00, 0x01, 0x30, 0x00, 0x06, 0x41, 0x0a, 0x0a, 0x0a,
+				0x00, 0x00, 0x00, 0x02, 0x30, 0x00, 0x0c, 0x81, 0x0a, 0x0a, 0x0a,
+				0x00, 0x00, 0x00, 0x03, 0x30, 0x00, 0x12, 0xc1, 0x0a, 0x0a, 0x0a,
+			},
+			addPath: map[int]bool{16: true},
+			expect: map[Prefix][]RIBEvent{
+				{AFISAFI: AFISAFI{AFI: 1, SAFI: 4}, Prefix: netip.MustParsePrefix("10.10.10.0/24")}: {
+					{
+						Prefix:  Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 4}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+						PathID:  1,
+						Labels:  []uint32{100},
+						NextHop: "10.0.0.1",
+					},
+					{
+						Prefix:  Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 4}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+						PathID:  2,
+						Labels:  []uint32{200},
+						NextHop: "10.0.0.1",
+					},
+					{
+						Prefix:  Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 4}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+						PathID:  3,
+						Labels:  []uint32{300},
+						NextHop: "10.0.0.1",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal BGP Update with error: %+v", err)
+			}
+			events, err := u.GetRIBEvents(tt.addPath)
+			if err != nil {
+				t.Fatalf("failed to get RIB events with error: %+v", err)
+			}
+			got := GroupByPrefix(events)
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected groups do not match the actual")
+			}
+		})
+	}
+}
+
+func TestIsNextHopSelf(t *testing.T) {
+	tests := []struct {
+		name    string
+		nextHop string
+		peer    string
+		expect  bool
+	}{
+		{
+			name:    "ipv4 next hop self",
+			nextHop: "192.168.80.103",
+			peer:    "192.168.80.103",
+			expect:  true,
+		},
+		{
+			name:    "ipv4 next hop unchanged",
+			nextHop: "10.0.0.1",
+			peer:    "192.168.80.103",
+			expect:  false,
+		},
+		{
+			name:    "ipv6 global and link local next hop self",
+			nextHop: "2001:db8::1,fe80::1",
+			peer:    "2001:db8::1",
+			expect:  true,
+		},
+		{
+			name:    "ipv6 next hop unchanged",
+			nextHop: "2001:db8::2,fe80::2",
+			peer:    "2001:db8::1",
+			expect:  false,
+		},
+		{
+			name:    "ipv4 mapped ipv6 next hop self",
+			nextHop: "::ffff:192.168.80.103",
+			peer:    "192.168.80.103",
+			expect:  true,
+		},
+		{
+			name:    "empty next hop",
+			nextHop: "",
+			peer:    "192.168.80.103",
+			expect:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNextHopSelf(tt.nextHop, tt.peer); got != tt.expect {
+				t.Fatalf("expected next hop self %t, got %t", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestKafkaKey(t *testing.T) {
+	prefix := Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")}
+	add := RIBEvent{Prefix: prefix, PathID: 1, NextHop: "10.0.0.1"}
+	withdraw := RIBEvent{Prefix: prefix, Withdraw: true}
+	if !reflect.DeepEqual(KafkaKey(add), KafkaKey(withdraw)) {
+		t.Fatalf("expected the same key for add and withdraw, got %s and %s", KafkaKey(add), KafkaKey(withdraw))
+	}
+	others := []RIBEvent{
+		{Prefix: Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.11.0/24")}},
+		{Prefix: Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 4}, Prefix: netip.MustParsePrefix("10.10.10.0/24")}},
+		{Prefix: Prefix{AFISAFI: AFISAFI{AFI: 1, SAFI: 128}, RD: "65000:100", Prefix: netip.MustParsePrefix("10.10.10.0/24")}},
+	}
+	for _, e := range others {
+		if reflect.DeepEqual(KafkaKey(add), KafkaKey(e)) {
+			t.Fatalf("expected different keys for %+v and %+v", add.Prefix, e.Prefix)
+		}
+	}
+}
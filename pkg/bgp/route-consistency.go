@@ -0,0 +1,145 @@
+package bgp
+
+import (
+	"fmt"
+)
+
+// WarningType defines a type of anomaly found in route's attributes
+type WarningType int
+
+const (
+	// AtomicAggregateWithoutAggregator flags ATOMIC_AGGREGATE attribute present without AGGREGATOR
+	AtomicAggregateWithoutAggregator WarningType = iota + 1
+	// AggregatorNotInASPath flags AGGREGATOR's AS which is not found in AS_PATH
+	AggregatorNotInASPath
+	// IncompleteOriginWithASPath flags ORIGIN INCOMPLETE for a route which has traversed other ASes
+	IncompleteOriginWithASPath
+	// OriginatorWithoutClusterList flags ORIGINATOR_ID attribute present without CLUSTER_LIST
+	OriginatorWithoutClusterList
+	// MalformedAggregator flags AGGREGATOR attribute of invalid length or with oversized aggregator id
+	MalformedAggregator
+	// ReservedASNInASPath flags AS_PATH containing reserved ASNs, see IsReservedASN
+	ReservedASNInASPath
+	// ReservedASNInAggregator flags AGGREGATOR's AS which is a reserved ASN, see IsReservedASN
+	ReservedASNInAggregator
+)
+
+// Warning defines an anomaly found in route's attributes
+type Warning struct {
+	Type    WarningType
+	Message string
+}
+
+// RouteConsistency checks route's attributes for common anomalies and returns a slice of found warnings,
+// empty slice is returned when no anomalies were found.
+func RouteConsistency(attrs *BaseAttributes) []Warning {
+	warnings := make([]Warning, 0)
+	if attrs == nil {
+		return warnings
+	}
+	if attrs.IsAtomicAgg && len(attrs.Aggregator) == 0 {
+		warnings = append(warnings, Warning{
+			Type:    AtomicAggregateWithoutAggregator,
+			Message: "atomic aggregate is set but aggregator is missing",
+		})
+	}
+	if len(attrs.Aggregator) != 0 {
+		agg, err := attrs.GetAggregator()
+		if err != nil {
+			warnings = append(warnings, Warning{
+				Type:    MalformedAggregator,
+				Message: err.Error(),
+			})
+		} else {
+			if agg.Malformed {
+				warnings = append(warnings, Warning{
+					Type:    MalformedAggregator,
+					Message: fmt.Sprintf("oversized aggregator id of %d bytes", len(attrs.Aggregator)),
+				})
+			}
+			if IsReservedASN(agg.AS) {
+				warnings = append(warnings, Warning{
+					Type:    ReservedASNInAggregator,
+					Message: fmt.Sprintf("aggregator as %d is reserved", agg.AS),
+				})
+			}
+			path := attrs.ASPath
+			if len(attrs.AS4Path) != 0 {
+				path = attrs.AS4Path
+			}
+			// Locally aggregated routes propagated within the AS carry an empty AS_PATH
+			if len(path) != 0 && !containsAS(path, agg.AS) {
+				warnings = append(warnings, Warning{
+					Type:    AggregatorNotInASPath,
+					Message: fmt.Sprintf("aggregator as %d is not found in as path %v", agg.AS, path),
+				})
+			}
+		}
+	}
+	// When AS4_PATH is present, AS_PATH legitimately carries AS_TRANS in place of 4 bytes ASNs
+	path := attrs.ASPath
+	if len(attrs.AS4Path) != 0 {
+		path = attrs.AS4Path
+	}
+	if reserved := ReservedASNsInPath(path); len(reserved) != 0 {
+		warnings = append(warnings, Warning{
+			Type:    ReservedASNInASPath,
+			Message: fmt.Sprintf("reserved as %v found in as path %v", reserved, path),
+		})
+	}
+	if attrs.Origin == "incomplete" && len(attrs.ASPath) > 1 {
+		warnings = append(warnings, Warning{
+			Type:    IncompleteOriginWithASPath,
+			Message: fmt.Sprintf("origin is incomplete for a route with as path %v", attrs.ASPath),
+		})
+	}
+	if attrs.OriginatorID != "" && attrs.ClusterList == "" {
+		warnings = append(warnings, Warning{
+			Type:    OriginatorWithoutClusterList,
+			Message: fmt.Sprintf("originator id %s is set but cluster list is missing", attrs.OriginatorID),
+		})
+	}
+
+	return warnings
+}
+
+// IsReservedASN returns true if as must not appear in AS_PATH or AGGREGATOR: AS 0 (RFC 7607),
+// AS_TRANS 23456 (RFC 6793), documentation ASNs 64496-64511 and 65536-65551 (RFC 5398)
+// and the last ASNs 65535 and 4294967295 (RFC 7300).
+func IsReservedASN(as uint32) bool {
+	switch {
+	case as == 0:
+	case as == asTrans:
+	case as >= 64496 && as <= 64511:
+	case as == 65535:
+	case as >= 65536 && as <= 65551:
+	case as == 4294967295:
+	default:
+		return false
+	}
+
+	return true
+}
+
+// ReservedASNsInPath returns reserved ASNs found in asPath in the order of appearance, each ASN is returned once,
+// empty slice is returned when no reserved ASNs were found.
+func ReservedASNsInPath(asPath []uint32) []uint32 {
+	reserved := make([]uint32, 0)
+	for _, as := range asPath {
+		if IsReservedASN(as) && !containsAS(reserved, as) {
+			reserved = append(reserved, as)
+		}
+	}
+
+	return reserved
+}
+
+func containsAS(path []uint32, as uint32) bool {
+	for _, a := range path {
+		if a == as {
+			return true
+		}
+	}
+
+	return false
+}
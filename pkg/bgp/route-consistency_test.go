@@ -0,0 +1,146 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRouteConsistency(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *BaseAttributes
+		expect []WarningType
+	}{
+		{
+			name: "consistent attributes",
+			input: &BaseAttributes{
+				Origin:      "igp",
+				ASPath:      []uint32{34872, 25888},
+				IsAtomicAgg: true,
+				Aggregator:  []byte{0, 0, 101, 32, 192, 120, 81, 136},
+			},
+			expect: []WarningType{},
+		},
+		{
+			name: "atomic aggregate without aggregator",
+			input: &BaseAttributes{
+				Origin:      "igp",
+				ASPath:      []uint32{34872, 25888},
+				IsAtomicAgg: true,
+			},
+			expect: []WarningType{AtomicAggregateWithoutAggregator},
+		},
+		{
+			name: "aggregator as not in as path",
+			input: &BaseAttributes{
+				Origin:     "igp",
+				ASPath:     []uint32{34872, 4809},
+				Aggregator: []byte{0, 0, 101, 32, 192, 120, 81, 136},
+			},
+			expect: []WarningType{AggregatorNotInASPath},
+		},
+		{
+			name: "2 bytes aggregator as_trans resolved by as4 aggregator",
+			input: &BaseAttributes{
+				Origin:        "igp",
+				ASPath:        []uint32{34872, 23456},
+				AS4Path:       []uint32{34872, 263096},
+				Aggregator:    []byte{0x5b, 0xa0, 192, 120, 81, 136},
+				AS4Aggregator: []byte{0, 0x04, 0x03, 0xb8, 192, 120, 81, 136},
+			},
+			expect: []WarningType{},
+		},
+		{
+			name: "malformed aggregator",
+			input: &BaseAttributes{
+				Origin:     "igp",
+				ASPath:     []uint32{34872},
+				Aggregator: []byte{0, 0, 101},
+			},
+			expect: []WarningType{MalformedAggregator},
+		},
+		{
+			name: "incomplete origin with full as path",
+			input: &BaseAttributes{
+				Origin: "incomplete",
+				ASPath: []uint32{34872, 39533, 6453},
+			},
+			expect: []WarningType{IncompleteOriginWithASPath},
+		},
+		{
+			name: "originator id without cluster list",
+			input: &BaseAttributes{
+				Origin:       "igp",
+				OriginatorID: "10.0.0.1",
+			},
+			expect: []WarningType{OriginatorWithoutClusterList},
+		},
+		{
+			name: "as 0 in as path and aggregator",
+			input: &BaseAttributes{
+				Origin:     "igp",
+				ASPath:     []uint32{34872, 0},
+				Aggregator: []byte{0, 0, 0, 0, 192, 120, 81, 136},
+			},
+			expect: []WarningType{ReservedASNInAggregator, ReservedASNInASPath},
+		},
+		{
+			name: "leaked as_trans without as4 path",
+			input: &BaseAttributes{
+				Origin: "igp",
+				ASPath: []uint32{34872, 23456},
+			},
+			expect: []WarningType{ReservedASNInASPath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RouteConsistency(tt.input)
+			if len(got) != len(tt.expect) {
+				t.Fatalf("expected %d warnings, got %d: %+v", len(tt.expect), len(got), got)
+			}
+			for i, w := range got {
+				if w.Type != tt.expect[i] {
+					t.Errorf("expected warning type %d, got %d: %s", tt.expect[i], w.Type, w.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestReservedASNsInPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []uint32
+		expect []uint32
+	}{
+		{
+			name:   "no reserved asns",
+			input:  []uint32{34872, 25888, 4200000000},
+			expect: []uint32{},
+		},
+		{
+			name:   "as 0",
+			input:  []uint32{34872, 0, 25888},
+			expect: []uint32{0},
+		},
+		{
+			name:   "as_trans and documentation asns",
+			input:  []uint32{23456, 64496, 34872, 64511, 64496, 65551},
+			expect: []uint32{23456, 64496, 64511, 65551},
+		},
+		{
+			name:   "last asns",
+			input:  []uint32{65535, 4294967295},
+			expect: []uint32{65535, 4294967295},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReservedASNsInPath(tt.input)
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected reserved asns %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package bgp
+
+// SessionContext carries parameters negotiated by BGP peers in OPEN messages which affect decoding
+// of BGP Updates exchanged over the session.
+type SessionContext struct {
+	// AS4 is set when both peers advertised Support for 4-octet AS number capability (65), AS_PATH
+	// then carries 4 bytes ASes, otherwise AS_PATH carries 2 bytes ASes and 4 bytes ASes are
+	// reconstructed from AS4_PATH, RFC 6793.
+	AS4 bool
+	// AddPath carries address families, keyed by NLRIMessageType, for which both peers advertised Add Path
+	// Send/Receive capability (69), it is nil when OPEN messages are not known. When it is set, BGP Update's
+	// IPv4 Unicast NLRI is decoded with or without Path Identifiers and checked to end with the message.
+	AddPath map[int]bool
+	// LocalAS is the AS number of the monitored router found in the OPEN message it sent
+	LocalAS uint32
+	// RawNLRI requests decoded Updates to retain copies of MP_REACH_NLRI and MP_UNREACH_NLRI
+	// NLRI bytes per address family, see Update's RawNLRI and RawWithdrawnNLRI.
+	RawNLRI bool
+	// CollectAttributeErrors requests decoding of BGP Update to continue when a path attribute fails to decode,
+	// the attribute is excluded from the Update and the error is collected in Update's AttributeErrors.
+	CollectAttributeErrors bool
+}
+
+// NewSessionContext builds SessionContext from OPEN messages sent and received by the monitored router
+func NewSessionContext(sent, received *OpenMessage) *SessionContext {
+	ctx := &SessionContext{}
+	if sent == nil || received == nil {
+		return ctx
+	}
+	_, s := sent.Is4BytesASCapable()
+	_, r := received.Is4BytesASCapable()
+	ctx.AS4 = s && r
+	ctx.AddPath = make(map[int]bool)
+	rAddPath := received.AddPathCapability()
+	for k, capable := range sent.AddPathCapability() {
+		if rCapable, ok := rAddPath[k]; ok {
+			ctx.AddPath[k] = capable && rCapable
+		}
+	}
+	ctx.LocalAS = sent.GetAS()
+
+	return ctx
+}
@@ -0,0 +1,163 @@
+package bgp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewSessionContext(t *testing.T) {
+	as4 := &OpenMessage{
+		Capabilities: Capability{
+			65: []*CapabilityData{{Value: []byte{0x00, 0x04, 0x03, 0xb8}}},
+		},
+	}
+	as2 := &OpenMessage{
+		Capabilities: Capability{},
+	}
+	// Add Path Send/Receive for IPv4 Unicast and IPv6 Unicast
+	addPath := &OpenMessage{
+		Capabilities: Capability{
+			69: []*CapabilityData{{Value: []byte{0x00, 0x01, 0x01, 0x03, 0x00, 0x02, 0x01, 0x03}}},
+		},
+	}
+	// Add Path Send/Receive for IPv4 Unicast and Receive only for IPv6 Unicast
+	addPathReceive := &OpenMessage{
+		Capabilities: Capability{
+			69: []*CapabilityData{{Value: []byte{0x00, 0x01, 0x01, 0x03, 0x00, 0x02, 0x01, 0x01}}},
+		},
+	}
+	tests := []struct {
+		name     string
+		sent     *OpenMessage
+		received *OpenMessage
+		expect   bool
+		addPath  map[int]bool
+	}{
+		{
+			name:     "both peers 4-octet as capable",
+			sent:     as4,
+			received: as4,
+			expect:   true,
+			addPath:  map[int]bool{},
+		},
+		{
+			name:     "only sent open is 4-octet as capable",
+			sent:     as4,
+			received: as2,
+			expect:   false,
+			addPath:  map[int]bool{},
+		},
+		{
+			name:     "missing received open",
+			sent:     as4,
+			received: nil,
+			expect:   false,
+		},
+		{
+			name:     "add path send receive",
+			sent:     addPath,
+			received: addPathReceive,
+			expect:   false,
+			addPath:  map[int]bool{NLRIMessageType(1, 1): true, NLRIMessageType(2, 1): false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewSessionContext(tt.sent, tt.received)
+			if ctx.AS4 != tt.expect {
+				t.Fatalf("expected as4 %t, got %t", tt.expect, ctx.AS4)
+			}
+			if !reflect.DeepEqual(tt.addPath, ctx.AddPath) {
+				t.Fatalf("expected add path %+v, got %+v", tt.addPath, ctx.AddPath)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBaseAttributesWithContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		ctx    *SessionContext
+		expect []uint32
+		fail   bool
+	}{
+		{
+			name:   "4-octet as negotiated",
+			input:  []byte{0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x64, 0x02, 0x00},
+			ctx:    &SessionContext{AS4: true},
+			expect: []uint32{6554112},
+		},
+		{
+			name:   "4-octet as not negotiated",
+			input:  []byte{0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x64, 0x02, 0x00},
+			ctx:    &SessionContext{AS4: false},
+			expect: []uint32{100},
+		},
+		{
+			name: "4-octet as not negotiated as path reconstructed from as4 path",
+			input: []byte{
+				0x40, 0x02, 0x06, 0x02, 0x02, 0x88, 0x38, 0x5b, 0xa0,
+				0xc0, 0x11, 0x0a, 0x02, 0x02, 0x00, 0x00, 0x88, 0x38, 0x00, 0x04, 0x03, 0xb8,
+			},
+			ctx:    &SessionContext{AS4: false},
+			expect: []uint32{34872, 263096},
+		},
+		{
+			name: "4-octet as not negotiated as4 path longer than as path",
+			input: []byte{
+				0x40, 0x02, 0x04, 0x02, 0x01, 0x5b, 0xa0,
+				0xc0, 0x11, 0x0a, 0x02, 0x02, 0x00, 0x00, 0x88, 0x38, 0x00, 0x04, 0x03, 0xb8,
+			},
+			ctx:    &SessionContext{AS4: false},
+			expect: []uint32{23456},
+		},
+		{
+			name: "4-octet as not negotiated as set counted as one as",
+			input: []byte{
+				0x40, 0x02, 0x10, 0x03, 0x01, 0xfd, 0xe9, 0x02, 0x01, 0x5b, 0xa0, 0x01, 0x03, 0x01, 0x2c, 0x01, 0x90, 0x01, 0xf4,
+				0xc0, 0x11, 0x0e, 0x02, 0x03, 0x00, 0x04, 0x03, 0xb8, 0x00, 0x04, 0x03, 0xb9, 0x00, 0x04, 0x03, 0xba,
+			},
+			ctx:    &SessionContext{AS4: false},
+			expect: []uint32{65001, 23456, 300, 400, 500},
+		},
+		{
+			name: "4-octet as not negotiated confed segments of as4 path discarded",
+			input: []byte{
+				0x40, 0x02, 0x10, 0x03, 0x01, 0xfd, 0xe9, 0x02, 0x02, 0x00, 0x64, 0x5b, 0xa0, 0x01, 0x02, 0x01, 0x2c, 0x5b, 0xa0,
+				0xc0, 0x11, 0x16, 0x03, 0x01, 0x00, 0x00, 0xfd, 0xe9, 0x02, 0x01, 0x00, 0x04, 0x03, 0xb8,
+				0x01, 0x02, 0x00, 0x00, 0x01, 0x2c, 0x00, 0x04, 0x03, 0xb9,
+			},
+			ctx:    &SessionContext{AS4: false},
+			expect: []uint32{65001, 100, 263096, 300, 263097},
+		},
+		{
+			name:  "4-octet as negotiated as path with 2 bytes ases",
+			input: []byte{0x40, 0x02, 0x04, 0x02, 0x01, 0x5b, 0xa0},
+			ctx:   &SessionContext{AS4: true},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unmarshalBGPBaseAttributes(tt.input, tt.ctx)
+			if err != nil {
+				var attrErr *AttributeError
+				if !tt.fail || !errors.As(err, &attrErr) || attrErr.AttributeType != 2 {
+					t.Fatalf("failed to unmarshal base attributes with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got.ASPath) {
+				t.Fatalf("expected as path %+v, got %+v", tt.expect, got.ASPath)
+			}
+			if got.ASPathCount != int32(len(tt.expect)) {
+				t.Fatalf("expected as path count %d, got %d", len(tt.expect), got.ASPathCount)
+			}
+		})
+	}
+}
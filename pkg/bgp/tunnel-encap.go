@@ -0,0 +1,193 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/tools"
+)
+
+const (
+	// TUNNEL_ENCAP defines BGP Tunnel Encapsulation attribute type
+	TUNNEL_ENCAP = 23
+
+	// TunnelColorSTLV defines Color Sub-TLV code of Tunnel Encapsulation attribute
+	TunnelColorSTLV = 4
+	// TunnelUDPDestPortSTLV defines UDP Destination Port Sub-TLV code of Tunnel Encapsulation attribute
+	TunnelUDPDestPortSTLV = 8
+)
+
+// EncapType defines BGP Tunnel Encapsulation Attribute Tunnel Type, the same values are used by
+// Tunnel Encapsulation attribute's Tunnel TLV and by Encapsulation Extended Community.
+// https://www.iana.org/assignments/bgp-parameters/bgp-parameters.xhtml#tunnel-types
+type EncapType uint16
+
+const (
+	// EncapL2TPv3 defines Tunnel Type 1, L2TPv3 over IP, RFC 9012
+	EncapL2TPv3 EncapType = 1
+	// EncapGRE defines Tunnel Type 2, GRE, RFC 9012
+	EncapGRE EncapType = 2
+	// EncapIPinIP defines Tunnel Type 7, IP in IP, RFC 9012
+	EncapIPinIP EncapType = 7
+	// EncapVXLAN defines Tunnel Type 8, VXLAN, RFC 9012
+	EncapVXLAN EncapType = 8
+	// EncapNVGRE defines Tunnel Type 9, NVGRE, RFC 9012
+	EncapNVGRE EncapType = 9
+	// EncapMPLS defines Tunnel Type 10, MPLS, RFC 9012
+	EncapMPLS EncapType = 10
+	// EncapMPLSinGRE defines Tunnel Type 11, MPLS in GRE, RFC 9012
+	EncapMPLSinGRE EncapType = 11
+	// EncapVXLANGPE defines Tunnel Type 12, VXLAN GPE, RFC 9012
+	EncapVXLANGPE EncapType = 12
+	// EncapMPLSinUDP defines Tunnel Type 13, MPLS in UDP, RFC 9012
+	EncapMPLSinUDP EncapType = 13
+	// EncapIPv6Tunnel defines Tunnel Type 14, IPv6 Tunnel
+	EncapIPv6Tunnel EncapType = 14
+	// EncapSRTEPolicy defines Tunnel Type 15, SR Policy, RFC 9830
+	EncapSRTEPolicy EncapType = 15
+	// EncapBare defines Tunnel Type 16, Bare
+	EncapBare EncapType = 16
+	// EncapSRTunnel defines Tunnel Type 17, SR Tunnel
+	EncapSRTunnel EncapType = 17
+	// EncapCloudSec defines Tunnel Type 18, Cloud Security
+	EncapCloudSec EncapType = 18
+	// EncapGENEVE defines Tunnel Type 19, Geneve
+	EncapGENEVE EncapType = 19
+)
+
+var encapTypeNames = map[EncapType]string{
+	EncapL2TPv3:     "L2TPv3",
+	EncapGRE:        "GRE",
+	EncapIPinIP:     "IP-in-IP",
+	EncapVXLAN:      "VXLAN",
+	EncapNVGRE:      "NVGRE",
+	EncapMPLS:       "MPLS",
+	EncapMPLSinGRE:  "MPLS-in-GRE",
+	EncapVXLANGPE:   "VXLAN-GPE",
+	EncapMPLSinUDP:  "MPLS-in-UDP",
+	EncapIPv6Tunnel: "IPv6-Tunnel",
+	EncapSRTEPolicy: "SR-TE-Policy",
+	EncapBare:       "Bare",
+	EncapSRTunnel:   "SR-Tunnel",
+	EncapCloudSec:   "Cloud-Security",
+	EncapGENEVE:     "GENEVE",
+}
+
+// String returns the name of the tunnel type, for unassigned types the numeric value is returned
+func (e EncapType) String() string {
+	if n, ok := encapTypeNames[e]; ok {
+		return n
+	}
+
+	return fmt.Sprintf("%d", uint16(e))
+}
+
+// TunnelSubTLV defines a raw Sub-TLV of Tunnel TLV
+type TunnelSubTLV struct {
+	Type  uint8
+	Value []byte
+}
+
+// Tunnel defines a Tunnel TLV of Tunnel Encapsulation attribute
+// https://www.rfc-editor.org/rfc/rfc9012#section-2
+type Tunnel struct {
+	Type EncapType
+	// Color carries values of all Color Sub-TLVs found in the tunnel, when present, the route
+	// is steered into SR Policy with matching color.
+	Color []uint32
+	// UDPDestPort carries the value of UDP Destination Port Sub-TLV, used by VXLAN and similar
+	// UDP based encapsulations, 0 when not present.
+	UDPDestPort uint16
+	SubTLV      []TunnelSubTLV
+}
+
+// GetSteeringColor returns the color of SR Policy the route should be steered into and true,
+// if the tunnel does not carry Color Sub-TLV, false is returned.
+func (t *Tunnel) GetSteeringColor() (uint32, bool) {
+	if len(t.Color) == 0 {
+		return 0, false
+	}
+
+	return t.Color[0], true
+}
+
+// TunnelEncapsulation defines BGP Tunnel Encapsulation attribute (23) object
+type TunnelEncapsulation struct {
+	Tunnels []*Tunnel
+}
+
+// UnmarshalTunnelEncapsulation builds Tunnel Encapsulation attribute object
+func UnmarshalTunnelEncapsulation(b []byte) (*TunnelEncapsulation, error) {
+	if glog.V(6) {
+		glog.Infof("Tunnel Encapsulation Raw: %s", tools.MessageHex(b))
+	}
+	te := &TunnelEncapsulation{
+		Tunnels: make([]*Tunnel, 0),
+	}
+	for p := 0; p < len(b); {
+		if p+4 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal tunnel tlv")
+		}
+		t := &Tunnel{
+			Type:   EncapType(binary.BigEndian.Uint16(b[p : p+2])),
+			SubTLV: make([]TunnelSubTLV, 0),
+		}
+		l := int(binary.BigEndian.Uint16(b[p+2 : p+4]))
+		p += 4
+		if p+l > len(b) {
+			return nil, fmt.Errorf("invalid tunnel tlv length %d", l)
+		}
+		if err := t.unmarshalSubTLVs(b[p : p+l]); err != nil {
+			return nil, err
+		}
+		te.Tunnels = append(te.Tunnels, t)
+		p += l
+	}
+
+	return te, nil
+}
+
+func (t *Tunnel) unmarshalSubTLVs(b []byte) error {
+	for p := 0; p < len(b); {
+		st := b[p]
+		p++
+		// Sub-TLVs with type 0 - 127 have 1 byte length, with type 128 - 255 have 2 bytes length
+		var l int
+		if st < 128 {
+			if p+1 > len(b) {
+				return fmt.Errorf("not enough bytes to unmarshal tunnel sub tlv %d", st)
+			}
+			l = int(b[p])
+			p++
+		} else {
+			if p+2 > len(b) {
+				return fmt.Errorf("not enough bytes to unmarshal tunnel sub tlv %d", st)
+			}
+			l = int(binary.BigEndian.Uint16(b[p : p+2]))
+			p += 2
+		}
+		if p+l > len(b) {
+			return fmt.Errorf("invalid tunnel sub tlv %d length %d", st, l)
+		}
+		v := make([]byte, l)
+		copy(v, b[p:p+l])
+		switch st {
+		case TunnelColorSTLV:
+			// Value is formatted as Color Extended Community
+			if l != 8 || v[0] != 0x03 || v[1] != 0x0b {
+				return fmt.Errorf("invalid color sub tlv %s", tools.MessageHex(v))
+			}
+			t.Color = append(t.Color, binary.BigEndian.Uint32(v[4:]))
+		case TunnelUDPDestPortSTLV:
+			if l != 2 {
+				return fmt.Errorf("invalid udp destination port sub tlv length %d", l)
+			}
+			t.UDPDestPort = binary.BigEndian.Uint16(v)
+		}
+		t.SubTLV = append(t.SubTLV, TunnelSubTLV{Type: st, Value: v})
+		p += l
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestUnmarshalTunnelEncapsulation(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *TunnelEncapsulation
+		color  uint32
+		fail   bool
+	}{
+		{
+			name:  "vxlan with color 100 and udp port 4789",
+			input: []byte{0x00, 0x08, 0x00, 0x0e, 0x04, 0x08, 0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64, 0x08, 0x02, 0x12, 0xb5},
+			expect: &TunnelEncapsulation{
+				Tunnels: []*Tunnel{
+					{
+						Type:        8,
+						Color:       []uint32{100},
+						UDPDestPort: 4789,
+						SubTLV: []TunnelSubTLV{
+							{Type: 4, Value: []byte{0x03, 0x0b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64}},
+							{Type: 8, Value: []byte{0x12, 0xb5}},
+						},
+					},
+				},
+			},
+			color: 100,
+		},
+		{
+			name:  "invalid tunnel length",
+			input: []byte{0x00, 0x08, 0x00, 0x10, 0x08, 0x02, 0x12, 0xb5},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalTunnelEncapsulation(tt.input)
+			if err != nil && !tt.fail {
+				t.Fatalf("supposed to succeed but failed with error: %+v", err)
+			}
+			if err == nil && tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if tt.fail {
+				return
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected object does not match the actual")
+			}
+			color, ok := got.Tunnels[0].GetSteeringColor()
+			if !ok || color != tt.color {
+				t.Fatalf("expected steering color %d but got %d", tt.color, color)
+			}
+		})
+	}
+}
@@ -25,7 +25,8 @@ func (ls *NLRI) GetPrefixIGPRouteTag() []uint32 {
 		if tlv.Type != 1153 {
 			continue
 		}
-		for p := 0; p < len(tlv.Value); {
+		// Incomplete trailing tag if any, is ignored
+		for p := 0; p+4 <= len(tlv.Value); {
 			tag := binary.BigEndian.Uint32(tlv.Value[p : p+4])
 			tags = append(tags, tag)
 			p += 4
@@ -43,7 +44,8 @@ func (ls *NLRI) GetPrefixIGPExtRouteTag() []uint64 {
 		if tlv.Type != 1154 {
 			continue
 		}
-		for p := 0; p < len(tlv.Value); {
+		// Incomplete trailing tag if any, is ignored
+		for p := 0; p+8 <= len(tlv.Value); {
 			tag := binary.BigEndian.Uint64(tlv.Value[p : p+8])
 			tags = append(tags, tag)
 			p += 8
@@ -56,15 +58,30 @@ func (ls *NLRI) GetPrefixIGPExtRouteTag() []uint64 {
 
 // GetPrefixOSPFForwardAddr returns OSPF Forwarding Address
 func (ls *NLRI) GetPrefixOSPFForwardAddr() string {
+	if addr, ok := ls.GetOSPFForwardingAddress(); ok {
+		return addr.String()
+	}
+
+	return ""
+}
+
+// GetOSPFForwardingAddress returns the value of OSPF Forwarding Address TLV (1156) and true, the address
+// is IPv4 for OSPFv2 and IPv6 for OSPFv3 and carries the forwarding address of External and NSSA LSAs.
+// False is returned if the TLV is not present or its length is invalid.
+// https://www.rfc-editor.org/rfc/rfc9552#section-5.3.3.5
+func (ls *NLRI) GetOSPFForwardingAddress() (net.IP, bool) {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1156 {
 			continue
 		}
-		if tlv.Length == 4 {
-			return net.IP(tlv.Value).To4().String()
+		switch len(tlv.Value) {
+		case 4:
+			return net.IP(tlv.Value).To4(), true
+		case 16:
+			return net.IP(tlv.Value).To16(), true
 		}
-		return net.IP(tlv.Value).To16().String()
+		return nil, false
 	}
 
-	return ""
+	return nil, false
 }
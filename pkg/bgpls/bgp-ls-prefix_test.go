@@ -0,0 +1,89 @@
+package bgpls
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestGetPrefixIGPRouteTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []byte
+		routeTags   []uint32
+		extRouteTag []uint64
+	}{
+		{
+			name: "route tags and extended route tags",
+			input: []byte{
+				0x04, 0x81, 0x00, 0x08, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0xc8,
+				0x04, 0x82, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02,
+			},
+			routeTags:   []uint32{100, 200},
+			extRouteTag: []uint64{0x0000000100000002},
+		},
+		{
+			name: "truncated route tag",
+			input: []byte{
+				0x04, 0x81, 0x00, 0x06, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00,
+			},
+			routeTags: []uint32{100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			if got := nlri.GetPrefixIGPRouteTag(); !reflect.DeepEqual(tt.routeTags, got) {
+				t.Errorf("expected route tags %v, got %v", tt.routeTags, got)
+			}
+			if got := nlri.GetPrefixIGPExtRouteTag(); !reflect.DeepEqual(tt.extRouteTag, got) {
+				t.Errorf("expected extended route tags %v, got %v", tt.extRouteTag, got)
+			}
+		})
+	}
+}
+
+func TestGetOSPFForwardingAddress(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect net.IP
+		ok     bool
+	}{
+		{
+			name:   "ospfv2 forwarding address",
+			input:  []byte{0x04, 0x84, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x09},
+			expect: net.ParseIP("10.0.0.9").To4(),
+			ok:     true,
+		},
+		{
+			name:   "ospfv3 forwarding address",
+			input:  []byte{0x04, 0x84, 0x00, 0x10, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x09},
+			expect: net.ParseIP("2001:db8::9"),
+			ok:     true,
+		},
+		{
+			name:  "invalid length",
+			input: []byte{0x04, 0x84, 0x00, 0x02, 0x0a, 0x00},
+		},
+		{
+			name:  "no forwarding address",
+			input: []byte{0x04, 0x81, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			addr, ok := nlri.GetOSPFForwardingAddress()
+			if ok != tt.ok || !reflect.DeepEqual(tt.expect, addr) {
+				t.Errorf("expected forwarding address %s %t, got %s %t", tt.expect, tt.ok, addr, ok)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package bgpls
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -13,6 +14,10 @@ import (
 	"github.com/sbezverk/tools"
 )
 
+// ErrTLVNotFound is returned by accessors of BGP-LS attribute TLVs when the attribute does not carry
+// the TLV, it lets callers tell an absent TLV from a malformed one.
+var ErrTLVNotFound = errors.New("tlv not found")
+
 // NLRI defines BGP-LS NLRI object as collection of BGP-LS TLVs
 // https://tools.ietf.org/html/rfc7752#section-3.3
 type NLRI struct {
@@ -75,6 +80,18 @@ func (ls *NLRI) GetNodeFlags() (*NodeAttrFlags, error) {
 	return nil, fmt.Errorf("node found")
 }
 
+// GetNodeOpaqueAttribute returns Opaque Node Attribute object
+func (ls *NLRI) GetNodeOpaqueAttribute(proto base.ProtoID) (*NodeOpaqueAttribute, error) {
+	for _, tlv := range ls.LS {
+		if tlv.Type != 1025 {
+			continue
+		}
+		return UnmarshalNodeOpaqueAttribute(tlv.Value, proto)
+	}
+
+	return nil, ErrTLVNotFound
+}
+
 // GetNodeName returns Value field identifies the symbolic name of the router node
 func (ls *NLRI) GetNodeName() string {
 	for _, tlv := range ls.LS {
@@ -86,34 +103,47 @@ func (ls *NLRI) GetNodeName() string {
 	return ""
 }
 
-// GetISISAreaID returns a string IS-IS Area Identifier TLVs
-func (ls *NLRI) GetISISAreaID() string {
+// ISISAreaID defines IS-IS Area Identifier carried in TLV 1027
+type ISISAreaID []byte
+
+// String returns IS-IS Area Identifier in conventional dotted NET format, the first byte (AFI) is
+// followed by groups of 2 bytes, example 49.0001 or 49.0001.0002
+func (a ISISAreaID) String() string {
 	var s string
+	for p := 0; p < len(a); p++ {
+		if p%2 == 1 {
+			s += "."
+		}
+		s += fmt.Sprintf("%02x", a[p])
+	}
+
+	return s
+}
+
+// GetISISAreaIDs returns a slice of IS-IS Area Identifiers found in IS-IS Area Identifier TLVs
+func (ls *NLRI) GetISISAreaIDs() []ISISAreaID {
+	ids := make([]ISISAreaID, 0)
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1027 {
 			continue
 		}
-		for p := 0; p < len(tlv.Value); {
-			s += fmt.Sprintf("%02x", tlv.Value[p])
-			p++
-			if p >= len(tlv.Value) {
-				break
-			}
-			s += "."
-			s += fmt.Sprintf("%02x", tlv.Value[p])
-			p++
-			if p >= len(tlv.Value) {
-				break
-			}
-			s += fmt.Sprintf("%02x", tlv.Value[p])
-			p++
-			if p >= len(tlv.Value) {
-				break
-			}
-			if p < len(tlv.Value) {
-				s += ","
-			}
+		id := make(ISISAreaID, len(tlv.Value))
+		copy(id, tlv.Value)
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// GetISISAreaID returns a string IS-IS Area Identifier TLVs, when the node advertises multiple
+// areas, they are separated by comma
+func (ls *NLRI) GetISISAreaID() string {
+	var s string
+	for i, id := range ls.GetISISAreaIDs() {
+		if i > 0 {
+			s += ","
 		}
+		s += id.String()
 	}
 
 	return s
@@ -121,26 +151,55 @@ func (ls *NLRI) GetISISAreaID() string {
 
 // GetLocalIPv4RouterID returns string with local Node IPv4 router ID
 func (ls *NLRI) GetLocalIPv4RouterID() string {
+	if id, ok := ls.GetIPv4RouterID(); ok {
+		return id.String()
+	}
+
+	return ""
+}
+
+// GetLocalIPv6RouterID returns string with local Node IPv6 router ID
+func (ls *NLRI) GetLocalIPv6RouterID() string {
+	if id, ok := ls.GetIPv6RouterID(); ok {
+		return id.String()
+	}
+
+	return ""
+}
+
+// GetIPv4RouterID returns the value of IPv4 Router-ID of Local Node TLV (1028) and true, the Router-ID
+// is the node's loopback or management address and is independent of the IGP Router-ID.
+// False is returned if the TLV is not present or its length is invalid.
+// https://www.rfc-editor.org/rfc/rfc9552#section-5.3.1.4
+func (ls *NLRI) GetIPv4RouterID() (net.IP, bool) {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1028 {
 			continue
 		}
-		return net.IP(tlv.Value).To4().String()
+		if len(tlv.Value) != 4 {
+			return nil, false
+		}
+		return net.IP(tlv.Value).To4(), true
 	}
 
-	return ""
+	return nil, false
 }
 
-// GetLocalIPv6RouterID returns string with local Node IPv6 router ID
-func (ls *NLRI) GetLocalIPv6RouterID() string {
+// GetIPv6RouterID returns the value of IPv6 Router-ID of Local Node TLV (1029) and true.
+// False is returned if the TLV is not present or its length is invalid.
+// https://www.rfc-editor.org/rfc/rfc9552#section-5.3.1.4
+func (ls *NLRI) GetIPv6RouterID() (net.IP, bool) {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1029 {
 			continue
 		}
-		return net.IP(tlv.Value).To16().String()
+		if len(tlv.Value) != 16 {
+			return nil, false
+		}
+		return net.IP(tlv.Value).To16(), true
 	}
 
-	return ""
+	return nil, false
 }
 
 // GetRemoteIPv4RouterID returns string with remote Node IPv4 router ID
@@ -203,7 +262,8 @@ func (ls *NLRI) GetNodeSRCapabilities(proto base.ProtoID) (*sr.Capability, error
 	return nil, fmt.Errorf("not found")
 }
 
-// GetSRAlgorithm returns a list of SR Algorithms
+// GetSRAlgorithm returns a list of SR Algorithms supported by the node, carried in SR Algorithm TLV (1035),
+// 0 is SPF, 1 is Strict SPF and 128 - 255 are Flexible Algorithms.
 func (ls *NLRI) GetSRAlgorithm() []int {
 	a := make([]int, 0)
 	for _, tlv := range ls.LS {
@@ -219,6 +279,26 @@ func (ls *NLRI) GetSRAlgorithm() []int {
 	return a
 }
 
+// GetNodeAdminTags returns a list of 32 bit administrative tags assigned to the node, carried in
+// Node Administrative Tags TLV (1173) of Node NLRI's attributes.
+func (ls *NLRI) GetNodeAdminTags() ([]uint32, error) {
+	for _, tlv := range ls.LS {
+		if tlv.Type != 1173 {
+			continue
+		}
+		if len(tlv.Value)%4 != 0 {
+			return nil, fmt.Errorf("invalid length %d of node administrative tags tlv", len(tlv.Value))
+		}
+		tags := make([]uint32, 0, len(tlv.Value)/4)
+		for p := 0; p < len(tlv.Value); p += 4 {
+			tags = append(tags, binary.BigEndian.Uint32(tlv.Value[p:p+4]))
+		}
+		return tags, nil
+	}
+
+	return nil, ErrTLVNotFound
+}
+
 // GetNodeSRLocalBlock returns SR LocalBlock object
 func (ls *NLRI) GetNodeSRLocalBlock() *sr.LocalBlock {
 	for _, tlv := range ls.LS {
@@ -370,30 +450,61 @@ func (ls *NLRI) GetAdminGroup() uint32 {
 
 // GetTEDefaultMetric returns value of TE Default Metric
 func (ls *NLRI) GetTEDefaultMetric() uint32 {
-	for _, tlv := range ls.LS {
-		if tlv.Type != 1092 {
-			continue
-		}
-		return binary.BigEndian.Uint32(tlv.Value)
-	}
+	m, _ := ls.GetLinkMetric(SRMetricTE)
 
-	return 0
+	return m
 }
 
 // GetIGPMetric returns IGP Metric
 func (ls *NLRI) GetIGPMetric() uint32 {
+	m, _ := ls.GetLinkMetric(SRMetricIGP)
+
+	return m
+}
+
+// GetLinkMetric returns the link's metric of type t and true, so SPF could be computed over IGP Metric (1095),
+// TE Default Metric (1092) or minimum delay of Min/Max Unidirectional Link Delay (1115), false is returned
+// if the link does not carry the metric.
+func (ls *NLRI) GetLinkMetric(t SRMetricType) (uint32, bool) {
 	for _, tlv := range ls.LS {
-		if tlv.Type != 1095 {
-			continue
+		switch {
+		case t == SRMetricIGP && tlv.Type == 1095:
+			// 1095 TLV has variable length, 1 byte for IS-IS small metrics, 2 bytes for OSPF
+			// and 3 bytes for IS-IS wide metrics.
+			if len(tlv.Value) == 0 || len(tlv.Value) > 3 {
+				return 0, false
+			}
+			m := metricValue(tlv.Value)
+			if len(tlv.Value) == 1 {
+				// The two leftmost bits of IS-IS small metric are reserved
+				m &= 0x3f
+			}
+			return m, true
+		case t == SRMetricTE && tlv.Type == 1092:
+			// Some implementations encode TE Default Metric with 3 bytes of IS-IS TE metric
+			if len(tlv.Value) == 0 || len(tlv.Value) > 4 {
+				return 0, false
+			}
+			return metricValue(tlv.Value), true
+		case t == SRMetricMinUnidirLinkDelay && tlv.Type == 1115:
+			if len(tlv.Value) != 8 {
+				return 0, false
+			}
+			return teMetric24(tlv.Value[:4]), true
 		}
-		m := make([]byte, 4)
-		// 1095 TLV has varaible length
-		// 1, 2 or 3 bytes, depending on the length copying the actual value into the right position.
-		copy(m[4-tlv.Length:], tlv.Value)
-		return binary.BigEndian.Uint32(m)
 	}
 
-	return 0
+	return 0, false
+}
+
+// metricValue returns the value of big endian metric of up to 4 bytes
+func metricValue(b []byte) uint32 {
+	var m uint32
+	for _, v := range b {
+		m = m<<8 | uint32(v)
+	}
+
+	return m
 }
 
 // GetPrefixMetric returns  Prefix Metric
@@ -431,42 +542,52 @@ func (ls *NLRI) GetUnreservedLinkBandwidth() []uint32 {
 
 // GetMaxLinkBandwidthKbps returns value of Maximum Link Bandwidth in kbps
 func (ls *NLRI) GetMaxLinkBandwidthKbps() uint64 {
-	for _, tlv := range ls.LS {
-		if tlv.Type != 1089 {
-			continue
-		}
-		return uint64(math.Float32frombits(binary.BigEndian.Uint32(tlv.Value)) * 8 / 1000)
-	}
+	return bandwidthKbps(ls.GetMaxLinkBandwidthBytes())
+}
 
-	return 0
+// GetMaxLinkBandwidthBytes returns value of Maximum Link Bandwidth (1089) in bytes per second
+func (ls *NLRI) GetMaxLinkBandwidthBytes() float64 {
+	return ls.getTEBandwidth(1089)
 }
 
 // GetMaxReservableLinkBandwidthKbps returns value of Maximum Reservable Link Bandwidth in kbps
 func (ls *NLRI) GetMaxReservableLinkBandwidthKbps() uint64 {
-	for _, tlv := range ls.LS {
-		if tlv.Type != 1090 {
-			continue
-		}
-		return uint64(math.Float32frombits(binary.BigEndian.Uint32(tlv.Value)) * 8 / 1000)
-	}
+	return bandwidthKbps(ls.GetMaxReservableLinkBandwidthBytes())
+}
 
-	return 0
+// GetMaxReservableLinkBandwidthBytes returns value of Maximum Reservable Link Bandwidth (1090) in bytes per second
+func (ls *NLRI) GetMaxReservableLinkBandwidthBytes() float64 {
+	return ls.getTEBandwidth(1090)
 }
 
 // GetUnreservedLinkBandwidthKbps returns eight 64-bit number in kbps
 func (ls *NLRI) GetUnreservedLinkBandwidthKbps() []uint64 {
+	bw := ls.GetUnreservedLinkBandwidthBytes()
+	if bw == nil {
+		return nil
+	}
 	unResrved := make([]uint64, 8)
+	for i := range bw {
+		unResrved[i] = bandwidthKbps(bw[i])
+	}
+
+	return unResrved
+}
+
+// GetUnreservedLinkBandwidthBytes returns Unreserved Bandwidth (1091) of eight priority levels in bytes per second,
+// when TLV is of invalid length, all levels are returned as 0, nil is returned when TLV is not present.
+func (ls *NLRI) GetUnreservedLinkBandwidthBytes() []float64 {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1091 {
 			continue
 		}
-                tlvLen := len(tlv.Value)
-                if tlvLen != 32 {
-                        glog.Errorf("BGP-LS TLV 1091 invalid length: %d, returning default\n", tlvLen)
-                        return unResrved
-                }
-		for i, p := 0, 0; p < tlvLen; i, p = i+1, p+4 {
-			unResrved[i] = uint64(math.Float32frombits(binary.BigEndian.Uint32(tlv.Value[p:p+4])) * 8 / 1000)
+		unResrved := make([]float64, 8)
+		if len(tlv.Value) != 32 {
+			glog.Errorf("BGP-LS TLV 1091 invalid length: %d, returning default", len(tlv.Value))
+			return unResrved
+		}
+		for i, p := 0, 0; p < len(tlv.Value); i, p = i+1, p+4 {
+			unResrved[i] = decodeBandwidth(tlv.Value[p : p+4])
 		}
 		return unResrved
 	}
@@ -615,62 +736,84 @@ func (ls *NLRI) GetSRv6SIDStructure() *srv6.SIDStructure {
 	return nil
 }
 
-// GetUnidirLinkDelay returns value of Unidirectional Link Delay
+// GetUnidirLinkDelay returns value of Unidirectional Link Delay (1114) in microseconds, the Anomalous
+// flag and reserved bits preceding 24 bits delay are masked out, RFC 8571.
 func (ls *NLRI) GetUnidirLinkDelay() uint32 {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1114 {
 			continue
 		}
-		return binary.BigEndian.Uint32(tlv.Value)
+		if len(tlv.Value) != 4 {
+			return 0
+		}
+		return teMetric24(tlv.Value)
 	}
 
 	return 0
 }
 
-// GetUnidirLinkDelayMinMax returns minimum and maximum delay values between two
-//   directly connected IGP link-state neighbors of MUnidirectional Link Delay
+// GetUnidirLinkDelayMinMax returns minimum and maximum delay values in microseconds between two
+// directly connected IGP link-state neighbors of Min/Max Unidirectional Link Delay (1115)
 func (ls *NLRI) GetUnidirLinkDelayMinMax() []uint32 {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1115 {
 			continue
 		}
-		return []uint32{binary.BigEndian.Uint32(tlv.Value[:4]), binary.BigEndian.Uint32(tlv.Value[4:])}
+		if len(tlv.Value) != 8 {
+			return nil
+		}
+		return []uint32{teMetric24(tlv.Value[:4]), teMetric24(tlv.Value[4:])}
 	}
 
 	return nil
 }
 
-// GetUnidirDelayVariation returns a value of the link delay variation between two
-// directly connected IGP link-state neighbor
+// GetUnidirDelayVariation returns a value of the link delay variation in microseconds between two
+// directly connected IGP link-state neighbor, Unidirectional Delay Variation (1116)
 func (ls *NLRI) GetUnidirDelayVariation() uint32 {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1116 {
 			continue
 		}
-		return binary.BigEndian.Uint32(tlv.Value)
+		if len(tlv.Value) != 4 {
+			return 0
+		}
+		return teMetric24(tlv.Value)
 	}
 
 	return 0
 }
 
-// GetUnidirLinkLoss returns a value of the the loss (as a packet percentage) between two
-// directly connected IGP link-state neighbor
+// GetUnidirLinkLoss returns a value of the the loss between two directly connected IGP link-state neighbor,
+// Unidirectional Link Loss (1117), the value is in units of 0.000003 percent of packets.
 func (ls *NLRI) GetUnidirLinkLoss() uint32 {
 	for _, tlv := range ls.LS {
 		if tlv.Type != 1117 {
 			continue
 		}
-		return binary.BigEndian.Uint32(tlv.Value)
+		if len(tlv.Value) != 4 {
+			return 0
+		}
+		return teMetric24(tlv.Value)
 	}
 
 	return 0
 }
 
+// GetUnidirLinkLossPercent returns the loss between two directly connected IGP link-state neighbor
+// as a percentage of packets
+func (ls *NLRI) GetUnidirLinkLossPercent() float64 {
+	return float64(ls.GetUnidirLinkLoss()) * 0.000003
+}
+
 // GetUnidirResidualBandwidth returns a value of the the residual bandwidth between two
-// directly connected IGP link-state neighbor
+// directly connected IGP link-state neighbor, Unidirectional Residual Bandwidth (1118), as the raw
+// 32 bits word of the IEEE floating point number it carries.
+//
+// Deprecated: use GetUnidirResidualBandwidthKbps.
 func (ls *NLRI) GetUnidirResidualBandwidth() uint32 {
 	for _, tlv := range ls.LS {
-		if tlv.Type != 1118 {
+		if tlv.Type != 1118 || len(tlv.Value) != 4 {
 			continue
 		}
 		return binary.BigEndian.Uint32(tlv.Value)
@@ -680,10 +823,13 @@ func (ls *NLRI) GetUnidirResidualBandwidth() uint32 {
 }
 
 // GetUnidirAvailableBandwidth returns a value of the the available bandwidth between two
-// directly connected IGP link-state neighbor
+// directly connected IGP link-state neighbor, Unidirectional Available Bandwidth (1119), as the raw
+// 32 bits word of the IEEE floating point number it carries.
+//
+// Deprecated: use GetUnidirAvailableBandwidthKbps.
 func (ls *NLRI) GetUnidirAvailableBandwidth() uint32 {
 	for _, tlv := range ls.LS {
-		if tlv.Type != 1119 {
+		if tlv.Type != 1119 || len(tlv.Value) != 4 {
 			continue
 		}
 		return binary.BigEndian.Uint32(tlv.Value)
@@ -693,10 +839,13 @@ func (ls *NLRI) GetUnidirAvailableBandwidth() uint32 {
 }
 
 // GetUnidirUtilizedBandwidth returns a value of the the utilized bandwidth between two
-// directly connected IGP link-state neighbor
+// directly connected IGP link-state neighbor, Unidirectional Utilized Bandwidth (1120), as the raw
+// 32 bits word of the IEEE floating point number it carries.
+//
+// Deprecated: use GetUnidirUtilizedBandwidthKbps.
 func (ls *NLRI) GetUnidirUtilizedBandwidth() uint32 {
 	for _, tlv := range ls.LS {
-		if tlv.Type != 1120 {
+		if tlv.Type != 1120 || len(tlv.Value) != 4 {
 			continue
 		}
 		return binary.BigEndian.Uint32(tlv.Value)
@@ -705,6 +854,76 @@ func (ls *NLRI) GetUnidirUtilizedBandwidth() uint32 {
 	return 0
 }
 
+// GetUnidirResidualBandwidthKbps returns a value of the the residual bandwidth in kbps between two
+// directly connected IGP link-state neighbor, Unidirectional Residual Bandwidth (1118)
+func (ls *NLRI) GetUnidirResidualBandwidthKbps() uint64 {
+	return ls.getTEBandwidthKbps(1118)
+}
+
+// GetUnidirAvailableBandwidthKbps returns a value of the the available bandwidth in kbps between two
+// directly connected IGP link-state neighbor, Unidirectional Available Bandwidth (1119)
+func (ls *NLRI) GetUnidirAvailableBandwidthKbps() uint64 {
+	return ls.getTEBandwidthKbps(1119)
+}
+
+// GetUnidirUtilizedBandwidthKbps returns a value of the the utilized bandwidth in kbps between two
+// directly connected IGP link-state neighbor, Unidirectional Utilized Bandwidth (1120)
+func (ls *NLRI) GetUnidirUtilizedBandwidthKbps() uint64 {
+	return ls.getTEBandwidthKbps(1120)
+}
+
+// GetUnidirResidualBandwidthBytes returns Unidirectional Residual Bandwidth (1118) in bytes per second
+func (ls *NLRI) GetUnidirResidualBandwidthBytes() float64 {
+	return ls.getTEBandwidth(1118)
+}
+
+// GetUnidirAvailableBandwidthBytes returns Unidirectional Available Bandwidth (1119) in bytes per second
+func (ls *NLRI) GetUnidirAvailableBandwidthBytes() float64 {
+	return ls.getTEBandwidth(1119)
+}
+
+// GetUnidirUtilizedBandwidthBytes returns Unidirectional Utilized Bandwidth (1120) in bytes per second
+func (ls *NLRI) GetUnidirUtilizedBandwidthBytes() float64 {
+	return ls.getTEBandwidth(1120)
+}
+
+// getTEBandwidthKbps converts bandwidth encoded as IEEE floating point number in bytes per second to kbps
+func (ls *NLRI) getTEBandwidthKbps(t uint16) uint64 {
+	return bandwidthKbps(ls.getTEBandwidth(t))
+}
+
+// getTEBandwidth returns bandwidth of TLV t in bytes per second, 0 is returned when TLV is not present
+// or is of invalid length
+func (ls *NLRI) getTEBandwidth(t uint16) float64 {
+	for _, tlv := range ls.LS {
+		if tlv.Type != t {
+			continue
+		}
+		if len(tlv.Value) != 4 {
+			return 0
+		}
+		return decodeBandwidth(tlv.Value)
+	}
+
+	return 0
+}
+
+// decodeBandwidth decodes bandwidth encoded as 4 bytes IEEE floating point number in bytes per second,
+// RFC 3630 section 2.5.6, b must carry at least 4 bytes
+func decodeBandwidth(b []byte) float64 {
+	return float64(math.Float32frombits(binary.BigEndian.Uint32(b[:4])))
+}
+
+// bandwidthKbps converts bandwidth in bytes per second to kbps
+func bandwidthKbps(bw float64) uint64 {
+	return uint64(bw * 8 / 1000)
+}
+
+// teMetric24 returns 24 bits value of TE metric, the first byte carries flags or is reserved
+func teMetric24(b []byte) uint32 {
+	return binary.BigEndian.Uint32(b) & 0x00ffffff
+}
+
 // GetAppSpecLinkAttr returns a slice of Application Specifc Link Attributes
 func (ls *NLRI) GetAppSpecLinkAttr() ([]*AppSpecLinkAttr, error) {
 	aslas := make([]*AppSpecLinkAttr, 0)
@@ -723,6 +942,26 @@ func (ls *NLRI) GetAppSpecLinkAttr() ([]*AppSpecLinkAttr, error) {
 	return aslas, nil
 }
 
+// GetL2BundleMembers returns a slice of L2 Bundle Member Attributes
+func (ls *NLRI) GetL2BundleMembers() ([]*L2BundleMember, error) {
+	members := make([]*L2BundleMember, 0)
+	for _, tlv := range ls.LS {
+		if tlv.Type != 1172 {
+			continue
+		}
+		m, err := UnmarshalL2BundleMember(tlv.Value)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	if len(members) == 0 {
+		return nil, ErrTLVNotFound
+	}
+
+	return members, nil
+}
+
 // GetSRAdjacencySID returns SR Adjacency SID object
 func (ls *NLRI) GetSRAdjacencySID(proto base.ProtoID) ([]*sr.AdjacencySIDTLV, error) {
 	adjs := make([]*sr.AdjacencySIDTLV, 0)
@@ -741,6 +980,26 @@ func (ls *NLRI) GetSRAdjacencySID(proto base.ProtoID) ([]*sr.AdjacencySIDTLV, er
 	return adjs, nil
 }
 
+// AdjacencySIDs returns link's SR Adjacency SIDs split into primary and backup, backup Adjacency SIDs
+// carry B flag and together with primary ones allow to reconstruct TI-LFA protected paths.
+func (ls *NLRI) AdjacencySIDs(proto base.ProtoID) (primary, backup []*sr.AdjacencySIDTLV, err error) {
+	adjs, err := ls.GetSRAdjacencySID(proto)
+	if err != nil {
+		return nil, nil, err
+	}
+	primary = make([]*sr.AdjacencySIDTLV, 0)
+	backup = make([]*sr.AdjacencySIDTLV, 0)
+	for _, adj := range adjs {
+		if adj.IsBackup() {
+			backup = append(backup, adj)
+			continue
+		}
+		primary = append(primary, adj)
+	}
+
+	return primary, backup, nil
+}
+
 // UnmarshalBGPLSNLRI builds Prefix NLRI object
 func UnmarshalBGPLSNLRI(b []byte) (*NLRI, error) {
 	if glog.V(6) {
@@ -0,0 +1,521 @@
+package bgpls
+
+import (
+	"errors"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/sbezverk/gobmp/pkg/base"
+)
+
+func TestAdjacencySIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		proto   base.ProtoID
+		primary []uint32
+		backup  []uint32
+	}{
+		{
+			name: "isis link with primary and backup adjacency sids",
+			input: []byte{
+				0x04, 0x4b, 0x00, 0x07, 0x30, 0x00, 0x00, 0x00, 0x00, 0x5d, 0xc0,
+				0x04, 0x4b, 0x00, 0x07, 0x70, 0x00, 0x00, 0x00, 0x00, 0x5d, 0xc1,
+			},
+			proto:   base.ISISL2,
+			primary: []uint32{24000},
+			backup:  []uint32{24001},
+		},
+		{
+			name: "ospf link with backup adjacency sid only",
+			input: []byte{
+				0x04, 0x4b, 0x00, 0x07, 0xe0, 0x00, 0x00, 0x00, 0x00, 0x5d, 0xc2,
+			},
+			proto:   base.OSPFv2,
+			primary: []uint32{},
+			backup:  []uint32{24002},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			primary, backup, err := nlri.AdjacencySIDs(tt.proto)
+			if err != nil {
+				t.Fatalf("failed to get adjacency sids with error: %+v", err)
+			}
+			if len(primary) != len(tt.primary) || len(backup) != len(tt.backup) {
+				t.Fatalf("expected %d primary and %d backup sids, got %d and %d", len(tt.primary), len(tt.backup), len(primary), len(backup))
+			}
+			for i, adj := range primary {
+				if adj.SID != tt.primary[i] {
+					t.Errorf("expected primary sid %d, got %d", tt.primary[i], adj.SID)
+				}
+			}
+			for i, adj := range backup {
+				if adj.SID != tt.backup[i] {
+					t.Errorf("expected backup sid %d, got %d", tt.backup[i], adj.SID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetNodeName(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+	}{
+		{
+			name:   "node name",
+			input:  []byte{0x04, 0x02, 0x00, 0x07, 0x78, 0x72, 0x76, 0x39, 0x6b, 0x2d, 0x31},
+			expect: "xrv9k-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			if got := nlri.GetNodeName(); got != tt.expect {
+				t.Fatalf("expected node name %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetISISAreaID(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+	}{
+		{
+			name:   "single area",
+			input:  []byte{0x04, 0x03, 0x00, 0x03, 0x49, 0x00, 0x01},
+			expect: "49.0001",
+		},
+		{
+			name:   "multi byte area",
+			input:  []byte{0x04, 0x03, 0x00, 0x07, 0x39, 0x84, 0x0f, 0x80, 0x00, 0x00, 0x01},
+			expect: "39.840f.8000.0001",
+		},
+		{
+			name: "multiple areas",
+			input: []byte{
+				0x04, 0x03, 0x00, 0x03, 0x49, 0x00, 0x01,
+				0x04, 0x03, 0x00, 0x05, 0x49, 0x00, 0x01, 0x00, 0x02,
+			},
+			expect: "49.0001,49.0001.0002",
+		},
+		{
+			name:   "no area",
+			input:  []byte{0x04, 0x02, 0x00, 0x07, 0x78, 0x72, 0x76, 0x39, 0x6b, 0x2d, 0x31},
+			expect: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			if got := nlri.GetISISAreaID(); got != tt.expect {
+				t.Fatalf("expected area id %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetSRAlgorithm(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []int
+	}{
+		{
+			name:   "spf, strict spf and flex algo 128",
+			input:  []byte{0x04, 0x0b, 0x00, 0x03, 0x00, 0x01, 0x80},
+			expect: []int{0, 1, 128},
+		},
+		{
+			name:   "no sr algorithm tlv",
+			input:  []byte{0x04, 0x02, 0x00, 0x07, 0x78, 0x72, 0x76, 0x39, 0x6b, 0x2d, 0x31},
+			expect: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			if got := nlri.GetSRAlgorithm(); !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected sr algorithms %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetNodeAdminTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expect   []uint32
+		fail     bool
+		notFound bool
+	}{
+		{
+			name:   "two admin tags",
+			input:  []byte{0x04, 0x95, 0x00, 0x08, 0x00, 0x00, 0x00, 0x64, 0x00, 0x01, 0x00, 0x02},
+			expect: []uint32{100, 65538},
+		},
+		{
+			name:  "invalid length",
+			input: []byte{0x04, 0x95, 0x00, 0x03, 0x00, 0x00, 0x64},
+			fail:  true,
+		},
+		{
+			name:     "no admin tags tlv",
+			input:    []byte{0x04, 0x02, 0x00, 0x07, 0x78, 0x72, 0x76, 0x39, 0x6b, 0x2d, 0x31},
+			fail:     true,
+			notFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			got, err := nlri.GetNodeAdminTags()
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				if errors.Is(err, ErrTLVNotFound) != tt.notFound {
+					t.Fatalf("unexpected error %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected admin tags %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetUnidirLinkMetrics(t *testing.T) {
+	input := []byte{
+		// Unidirectional Link Delay 1000 with Anomalous flag
+		0x04, 0x5a, 0x00, 0x04, 0x80, 0x00, 0x03, 0xe8,
+		// Min/Max Unidirectional Link Delay 500/2000
+		0x04, 0x5b, 0x00, 0x08, 0x00, 0x00, 0x01, 0xf4, 0x00, 0x00, 0x07, 0xd0,
+		// Unidirectional Delay Variation 100
+		0x04, 0x5c, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64,
+		// Unidirectional Link Loss 3%
+		0x04, 0x5d, 0x00, 0x04, 0x00, 0x0f, 0x42, 0x40,
+		// Unidirectional Available Bandwidth 125000000 bytes per second
+		0x04, 0x5f, 0x00, 0x04, 0x4c, 0xee, 0x6b, 0x28,
+	}
+	nlri, err := UnmarshalBGPLSNLRI(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+	}
+	if got := nlri.GetUnidirLinkDelay(); got != 1000 {
+		t.Errorf("expected link delay 1000, got %d", got)
+	}
+	if got := nlri.GetUnidirLinkDelayMinMax(); !reflect.DeepEqual([]uint32{500, 2000}, got) {
+		t.Errorf("expected min/max link delay [500 2000], got %v", got)
+	}
+	if got := nlri.GetUnidirDelayVariation(); got != 100 {
+		t.Errorf("expected delay variation 100, got %d", got)
+	}
+	if got := nlri.GetUnidirLinkLoss(); got != 1000000 {
+		t.Errorf("expected link loss 1000000, got %d", got)
+	}
+	if got := nlri.GetUnidirLinkLossPercent(); math.Abs(got-3) > 1e-9 {
+		t.Errorf("expected link loss 3%%, got %f", got)
+	}
+	if got := nlri.GetUnidirAvailableBandwidthKbps(); got != 1000000 {
+		t.Errorf("expected available bandwidth 1000000 kbps, got %d", got)
+	}
+	if got := nlri.GetUnidirResidualBandwidthKbps(); got != 0 {
+		t.Errorf("expected no residual bandwidth, got %d", got)
+	}
+}
+
+func TestGetLinkMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect map[SRMetricType]uint32
+	}{
+		{
+			name: "te and igp metrics",
+			input: []byte{
+				// TE Default Metric 100
+				0x04, 0x44, 0x00, 0x04, 0x00, 0x00, 0x00, 0x64,
+				// IGP Metric 10 of IS-IS wide metric
+				0x04, 0x47, 0x00, 0x03, 0x00, 0x00, 0x0a,
+				// Min/Max Unidirectional Link Delay 500/2000
+				0x04, 0x5b, 0x00, 0x08, 0x00, 0x00, 0x01, 0xf4, 0x00, 0x00, 0x07, 0xd0,
+			},
+			expect: map[SRMetricType]uint32{SRMetricIGP: 10, SRMetricTE: 100, SRMetricMinUnidirLinkDelay: 500},
+		},
+		{
+			name: "is-is small metric and 3 bytes te metric",
+			input: []byte{
+				// TE Default Metric 20
+				0x04, 0x44, 0x00, 0x03, 0x00, 0x00, 0x14,
+				// IGP Metric 63 with reserved bits set
+				0x04, 0x47, 0x00, 0x01, 0xff,
+			},
+			expect: map[SRMetricType]uint32{SRMetricIGP: 63, SRMetricTE: 20},
+		},
+		{
+			name: "zero igp metric only",
+			input: []byte{
+				// IGP Metric 0 of OSPF
+				0x04, 0x47, 0x00, 0x02, 0x00, 0x00,
+			},
+			expect: map[SRMetricType]uint32{SRMetricIGP: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			got := make(map[SRMetricType]uint32)
+			for _, mt := range []SRMetricType{SRMetricIGP, SRMetricMinUnidirLinkDelay, SRMetricTE} {
+				if m, ok := nlri.GetLinkMetric(mt); ok {
+					got[mt] = m
+				}
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected metrics %+v, got %+v", tt.expect, got)
+			}
+			if nlri.GetIGPMetric() != tt.expect[SRMetricIGP] {
+				t.Fatalf("expected igp metric %d, got %d", tt.expect[SRMetricIGP], nlri.GetIGPMetric())
+			}
+			if nlri.GetTEDefaultMetric() != tt.expect[SRMetricTE] {
+				t.Fatalf("expected te default metric %d, got %d", tt.expect[SRMetricTE], nlri.GetTEDefaultMetric())
+			}
+		})
+	}
+}
+
+func TestGetLSSourceRouterID(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		advertiser string
+		expect     string
+		fail       bool
+	}{
+		{
+			name: "ipv4 originator differs from advertiser",
+			input: []byte{
+				0x04, 0x04, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x04, 0x93, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x09,
+			},
+			advertiser: "10.0.0.1",
+			expect:     "10.0.0.9",
+		},
+		{
+			name: "ipv6 originator",
+			input: []byte{
+				0x04, 0x04, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x04, 0x93, 0x00, 0x10, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x09,
+			},
+			advertiser: "10.0.0.1",
+			expect:     "2001:db8::9",
+		},
+		{
+			name: "invalid length",
+			input: []byte{
+				0x04, 0x93, 0x00, 0x02, 0x0a, 0x00,
+			},
+			fail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			got, err := nlri.GetLSSourceRouterID()
+			if tt.fail {
+				if err == nil {
+					t.Fatalf("supposed to fail but succeeded with source router id %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("supposed to succeed but failed with error: %+v", err)
+			}
+			if got != tt.expect {
+				t.Fatalf("expected source router id %s, got %s", tt.expect, got)
+			}
+			if adv := nlri.GetLocalIPv4RouterID(); adv != tt.advertiser {
+				t.Fatalf("expected advertiser router id %s, got %s", tt.advertiser, adv)
+			}
+			pr, err := nlri.GetPrefixAttrTLVs(base.ISISL2)
+			if err != nil {
+				t.Fatalf("failed to get prefix attribute tlvs with error: %+v", err)
+			}
+			if pr.SourceRouterID != tt.expect {
+				t.Fatalf("expected prefix attribute source router id %s, got %s", tt.expect, pr.SourceRouterID)
+			}
+		})
+	}
+}
+
+func TestPrefixAttrTLVsIsNodeSID(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		proto  base.ProtoID
+		expect bool
+	}{
+		{
+			name:   "isis prefix sid with n flag",
+			input:  []byte{0x04, 0x86, 0x00, 0x08, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10},
+			proto:  base.ISISL2,
+			expect: true,
+		},
+		{
+			name:   "isis prefix sid without n flag",
+			input:  []byte{0x04, 0x86, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10},
+			proto:  base.ISISL2,
+			expect: false,
+		},
+		{
+			name: "ospf prefix sid with prefix attribute n flag",
+			input: []byte{
+				0x04, 0x86, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+				0x04, 0x92, 0x00, 0x01, 0x40,
+			},
+			proto:  base.OSPFv2,
+			expect: true,
+		},
+		{
+			name:   "ospf prefix sid without prefix attribute flags",
+			input:  []byte{0x04, 0x86, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10},
+			proto:  base.OSPFv2,
+			expect: false,
+		},
+		{
+			name:   "ospf prefix attribute n flag without prefix sid",
+			input:  []byte{0x04, 0x92, 0x00, 0x01, 0x40},
+			proto:  base.OSPFv2,
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			pr, err := nlri.GetPrefixAttrTLVs(tt.proto)
+			if err != nil {
+				t.Fatalf("failed to get prefix attribute tlvs with error: %+v", err)
+			}
+			if got := pr.IsNodeSID(); got != tt.expect {
+				t.Fatalf("expected node sid %t, got %t", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestGetLinkBandwidth(t *testing.T) {
+	input := []byte{
+		// Maximum Link Bandwidth 10 Gbps
+		0x04, 0x41, 0x00, 0x04, 0x4e, 0x95, 0x02, 0xf9,
+		// Maximum Reservable Link Bandwidth 1 Gbps
+		0x04, 0x42, 0x00, 0x04, 0x4c, 0xee, 0x6b, 0x28,
+		// Unreserved Bandwidth 10 Gbps for priority 0 and 1 Gbps for the rest
+		0x04, 0x43, 0x00, 0x20,
+		0x4e, 0x95, 0x02, 0xf9, 0x4c, 0xee, 0x6b, 0x28, 0x4c, 0xee, 0x6b, 0x28, 0x4c, 0xee, 0x6b, 0x28,
+		0x4c, 0xee, 0x6b, 0x28, 0x4c, 0xee, 0x6b, 0x28, 0x4c, 0xee, 0x6b, 0x28, 0x4c, 0xee, 0x6b, 0x28,
+		// Unidirectional Available Bandwidth 10 Gbps
+		0x04, 0x5f, 0x00, 0x04, 0x4e, 0x95, 0x02, 0xf9,
+	}
+	nlri, err := UnmarshalBGPLSNLRI(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+	}
+	if got := nlri.GetMaxLinkBandwidthBytes(); got != 1.25e9 {
+		t.Errorf("expected max link bandwidth 1.25e9 bytes/sec, got %v", got)
+	}
+	if got := nlri.GetMaxLinkBandwidthKbps(); got != 10000000 {
+		t.Errorf("expected max link bandwidth 10000000 kbps, got %d", got)
+	}
+	if got := nlri.GetMaxReservableLinkBandwidthBytes(); got != 1.25e8 {
+		t.Errorf("expected max reservable link bandwidth 1.25e8 bytes/sec, got %v", got)
+	}
+	unreserved := []float64{1.25e9, 1.25e8, 1.25e8, 1.25e8, 1.25e8, 1.25e8, 1.25e8, 1.25e8}
+	if got := nlri.GetUnreservedLinkBandwidthBytes(); !reflect.DeepEqual(unreserved, got) {
+		t.Errorf("expected unreserved bandwidth %v bytes/sec, got %v", unreserved, got)
+	}
+	unreservedKbps := []uint64{10000000, 1000000, 1000000, 1000000, 1000000, 1000000, 1000000, 1000000}
+	if got := nlri.GetUnreservedLinkBandwidthKbps(); !reflect.DeepEqual(unreservedKbps, got) {
+		t.Errorf("expected unreserved bandwidth %v kbps, got %v", unreservedKbps, got)
+	}
+	if got := nlri.GetUnidirAvailableBandwidthBytes(); got != 1.25e9 {
+		t.Errorf("expected available bandwidth 1.25e9 bytes/sec, got %v", got)
+	}
+	if got := nlri.GetUnidirAvailableBandwidth(); got != 0x4e9502f9 {
+		t.Errorf("expected raw available bandwidth 0x4e9502f9, got 0x%08x", got)
+	}
+	if got := nlri.GetUnidirResidualBandwidthBytes(); got != 0 {
+		t.Errorf("expected no residual bandwidth, got %v", got)
+	}
+}
+
+func TestGetRouterID(t *testing.T) {
+	// IPv4 Router-ID of Local Node TLV 1028 and IPv6 Router-ID of Local Node TLV 1029
+	input := []byte{
+		0x04, 0x04, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x01,
+		0x04, 0x05, 0x00, 0x10, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+	}
+	nlri, err := UnmarshalBGPLSNLRI(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+	}
+	v4, ok := nlri.GetIPv4RouterID()
+	if !ok || !reflect.DeepEqual(net.ParseIP("10.0.0.1").To4(), v4) {
+		t.Errorf("expected ipv4 router id 10.0.0.1, got %s %t", v4, ok)
+	}
+	v6, ok := nlri.GetIPv6RouterID()
+	if !ok || !reflect.DeepEqual(net.ParseIP("2001:db8::1"), v6) {
+		t.Errorf("expected ipv6 router id 2001:db8::1, got %s %t", v6, ok)
+	}
+	if got := nlri.GetLocalIPv4RouterID(); got != "10.0.0.1" {
+		t.Errorf("expected ipv4 router id string 10.0.0.1, got %q", got)
+	}
+	if got := nlri.GetLocalIPv6RouterID(); got != "2001:db8::1" {
+		t.Errorf("expected ipv6 router id string 2001:db8::1, got %q", got)
+	}
+	// Router-ID of invalid length is not returned
+	nlri, err = UnmarshalBGPLSNLRI([]byte{0x04, 0x04, 0x00, 0x02, 0x0a, 0x00})
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+	}
+	if id, ok := nlri.GetIPv4RouterID(); ok {
+		t.Errorf("expected no ipv4 router id, got %s", id)
+	}
+}
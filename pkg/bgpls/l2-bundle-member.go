@@ -0,0 +1,51 @@
+package bgpls
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/tools"
+)
+
+// https://www.rfc-editor.org/rfc/rfc9085#section-2.2.3
+
+// L2BundleMember defines a structure of L2 Bundle Member Attributes TLV (1172), the member's
+// link attributes are carried as BGP-LS link attribute TLVs and can be accessed with NLRI's methods.
+type L2BundleMember struct {
+	Descriptor uint32 `json:"l2_bundle_member_descriptor"`
+	Attributes *NLRI  `json:"-"`
+}
+
+// UnmarshalL2BundleMember builds L2 Bundle Member Attributes object
+func UnmarshalL2BundleMember(b []byte) (*L2BundleMember, error) {
+	if glog.V(6) {
+		glog.Infof("L2 Bundle Member Attributes Raw: %s", tools.MessageHex(b))
+	}
+	if len(b) < 4 {
+		return nil, fmt.Errorf("invalid length %d of l2 bundle member attributes tlv", len(b))
+	}
+	m := &L2BundleMember{
+		Descriptor: binary.BigEndian.Uint32(b[:4]),
+		Attributes: &NLRI{
+			LS: make([]TLV, 0),
+		},
+	}
+	for p := 4; p < len(b); {
+		if p+4 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal l2 bundle member %d attribute", m.Descriptor)
+		}
+		l := int(binary.BigEndian.Uint16(b[p+2 : p+4]))
+		if p+4+l > len(b) {
+			return nil, fmt.Errorf("invalid length %d of l2 bundle member %d attribute", l, m.Descriptor)
+		}
+		p += 4 + l
+	}
+	tlvs, err := UnmarshalBGPLSTLV(b[4:])
+	if err != nil {
+		return nil, err
+	}
+	m.Attributes.LS = tlvs
+
+	return m, nil
+}
@@ -0,0 +1,82 @@
+package bgpls
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetL2BundleMembers(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		expect     []uint32
+		adminGroup []uint32
+		bandwidth  []uint64
+		fail       bool
+		notFound   bool
+	}{
+		{
+			name: "two members bundle",
+			input: []byte{
+				0x04, 0x94, 0x00, 0x14, 0x00, 0x00, 0x00, 0x01,
+				0x04, 0x40, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01,
+				0x04, 0x41, 0x00, 0x04, 0x4e, 0x95, 0x02, 0xf9,
+				0x04, 0x94, 0x00, 0x14, 0x00, 0x00, 0x00, 0x02,
+				0x04, 0x40, 0x00, 0x04, 0x00, 0x00, 0x00, 0x02,
+				0x04, 0x41, 0x00, 0x04, 0x4c, 0xee, 0x6b, 0x28,
+			},
+			expect:     []uint32{1, 2},
+			adminGroup: []uint32{1, 2},
+			bandwidth:  []uint64{10000000, 1000000},
+		},
+		{
+			name: "invalid member attribute length",
+			input: []byte{
+				0x04, 0x94, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x01,
+				0x04, 0x40, 0x00, 0x08, 0x00, 0x00, 0x00, 0x01,
+			},
+			fail: true,
+		},
+		{
+			name:     "no bundle member tlv",
+			input:    []byte{0x04, 0x40, 0x00, 0x04, 0x00, 0x00, 0x00, 0x01},
+			fail:     true,
+			notFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			members, err := nlri.GetL2BundleMembers()
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("supposed to succeed but failed with error: %+v", err)
+				}
+				if errors.Is(err, ErrTLVNotFound) != tt.notFound {
+					t.Fatalf("unexpected error %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if len(members) != len(tt.expect) {
+				t.Fatalf("expected %d members, got %d", len(tt.expect), len(members))
+			}
+			for i, m := range members {
+				if m.Descriptor != tt.expect[i] {
+					t.Errorf("expected member descriptor %d, got %d", tt.expect[i], m.Descriptor)
+				}
+				if ag := m.Attributes.GetAdminGroup(); ag != tt.adminGroup[i] {
+					t.Errorf("expected member %d admin group %d, got %d", m.Descriptor, tt.adminGroup[i], ag)
+				}
+				if bw := m.Attributes.GetMaxLinkBandwidthKbps(); bw != tt.bandwidth[i] {
+					t.Errorf("expected member %d max link bandwidth %d, got %d", m.Descriptor, tt.bandwidth[i], bw)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package bgpls
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/gobmp/pkg/base"
+	"github.com/sbezverk/tools"
+)
+
+// ISISRouterCapabilityTLV defines IS-IS Router Capability TLV code
+const ISISRouterCapabilityTLV = 242
+
+// ISISTLV defines an IS-IS TLV which is not decoded
+type ISISTLV struct {
+	Type  uint8  `json:"type"`
+	Value []byte `json:"value,omitempty"`
+}
+
+// ISISRouterCapability defines IS-IS Router Capability TLV (242), sub TLVs are kept raw
+// https://tools.ietf.org/html/rfc7981#section-2
+type ISISRouterCapability struct {
+	RouterID string    `json:"router_id"`
+	SFlag    bool      `json:"s_flag"`
+	DFlag    bool      `json:"d_flag"`
+	SubTLV   []ISISTLV `json:"sub_tlvs,omitempty"`
+}
+
+// NodeOpaqueAttribute defines Opaque Node Attribute TLV (1025), for IS-IS nodes, when the opaque content is a valid
+// sequence of IS-IS TLVs, known TLVs are decoded and the rest are kept in TLV. Raw always carries the original value.
+// https://tools.ietf.org/html/rfc7752#section-3.3.1.5
+type NodeOpaqueAttribute struct {
+	Raw              []byte                  `json:"raw,omitempty"`
+	RouterCapability []*ISISRouterCapability `json:"router_capability,omitempty"`
+	TLV              []ISISTLV               `json:"tlvs,omitempty"`
+}
+
+// UnmarshalNodeOpaqueAttribute builds Opaque Node Attribute object, IS-IS TLVs are decoded only
+// for nodes of IS-IS protocols.
+func UnmarshalNodeOpaqueAttribute(b []byte, proto base.ProtoID) (*NodeOpaqueAttribute, error) {
+	if glog.V(6) {
+		glog.Infof("Opaque Node Attribute Raw: %s", tools.MessageHex(b))
+	}
+	o := &NodeOpaqueAttribute{
+		Raw: make([]byte, len(b)),
+	}
+	copy(o.Raw, b)
+	if proto != base.ISISL1 && proto != base.ISISL2 {
+		return o, nil
+	}
+	tlvs, err := unmarshalISISTLV(b)
+	if err != nil {
+		// Opaque content does not look like IS-IS TLVs, keeping it raw
+		return o, nil
+	}
+	for _, tlv := range tlvs {
+		if tlv.Type == ISISRouterCapabilityTLV {
+			c, err := unmarshalISISRouterCapability(tlv.Value)
+			if err == nil {
+				o.RouterCapability = append(o.RouterCapability, c)
+				continue
+			}
+		}
+		o.TLV = append(o.TLV, tlv)
+	}
+
+	return o, nil
+}
+
+// unmarshalISISTLV builds a slice of IS-IS TLVs, error is returned if b is not a sequence
+// of complete IS-IS TLVs.
+func unmarshalISISTLV(b []byte) ([]ISISTLV, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("no is-is tlvs found")
+	}
+	tlvs := make([]ISISTLV, 0)
+	for p := 0; p < len(b); {
+		if p+2 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal is-is tlv")
+		}
+		t := b[p]
+		l := int(b[p+1])
+		p += 2
+		if p+l > len(b) {
+			return nil, fmt.Errorf("invalid length %d of is-is tlv %d", l, t)
+		}
+		v := make([]byte, l)
+		copy(v, b[p:p+l])
+		tlvs = append(tlvs, ISISTLV{Type: t, Value: v})
+		p += l
+	}
+
+	return tlvs, nil
+}
+
+func unmarshalISISRouterCapability(b []byte) (*ISISRouterCapability, error) {
+	if len(b) < 5 {
+		return nil, fmt.Errorf("invalid length %d of is-is router capability tlv", len(b))
+	}
+	c := &ISISRouterCapability{
+		RouterID: net.IP(b[:4]).To4().String(),
+		DFlag:    b[4]&0x02 == 0x02,
+		SFlag:    b[4]&0x01 == 0x01,
+	}
+	if len(b) > 5 {
+		stlvs, err := unmarshalISISTLV(b[5:])
+		if err != nil {
+			return nil, err
+		}
+		c.SubTLV = stlvs
+	}
+
+	return c, nil
+}
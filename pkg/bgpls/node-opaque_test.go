@@ -0,0 +1,79 @@
+package bgpls
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/base"
+)
+
+func TestGetNodeOpaqueAttribute(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		proto  base.ProtoID
+		expect *NodeOpaqueAttribute
+	}{
+		{
+			name: "isis router capability and unknown tlv",
+			input: []byte{
+				0x04, 0x01, 0x00, 0x10,
+				0xf2, 0x09, 0x0a, 0x00, 0x00, 0x01, 0x01, 0x13, 0x02, 0x00, 0x80,
+				0x89, 0x03, 0x72, 0x31, 0x31,
+			},
+			proto: base.ISISL2,
+			expect: &NodeOpaqueAttribute{
+				Raw: []byte{0xf2, 0x09, 0x0a, 0x00, 0x00, 0x01, 0x01, 0x13, 0x02, 0x00, 0x80, 0x89, 0x03, 0x72, 0x31, 0x31},
+				RouterCapability: []*ISISRouterCapability{
+					{
+						RouterID: "10.0.0.1",
+						SFlag:    true,
+						SubTLV: []ISISTLV{
+							{Type: 19, Value: []byte{0x00, 0x80}},
+						},
+					},
+				},
+				TLV: []ISISTLV{
+					{Type: 137, Value: []byte{0x72, 0x31, 0x31}},
+				},
+			},
+		},
+		{
+			name: "opaque content is not isis tlvs",
+			input: []byte{
+				0x04, 0x01, 0x00, 0x03, 0xf2, 0x09, 0x0a,
+			},
+			proto: base.ISISL2,
+			expect: &NodeOpaqueAttribute{
+				Raw: []byte{0xf2, 0x09, 0x0a},
+			},
+		},
+		{
+			name: "ospf opaque content is kept raw",
+			input: []byte{
+				0x04, 0x01, 0x00, 0x05, 0x89, 0x03, 0x72, 0x31, 0x31,
+			},
+			proto: base.OSPFv2,
+			expect: &NodeOpaqueAttribute{
+				Raw: []byte{0x89, 0x03, 0x72, 0x31, 0x31},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalBGPLSNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp-ls nlri with error: %+v", err)
+			}
+			got, err := nlri.GetNodeOpaqueAttribute(tt.proto)
+			if err != nil {
+				t.Fatalf("failed to get opaque node attribute with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected opaque node attribute does not match the actual")
+			}
+		})
+	}
+}
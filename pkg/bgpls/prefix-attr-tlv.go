@@ -20,6 +20,29 @@ type PrefixAttrTLVs struct {
 	// TODO (sbezverk) Add "Source OSPF Router-ID" TLV 1174
 }
 
+// IsNodeSID returns true when the prefix carries Prefix SID which identifies the node advertising the prefix,
+// the node is indicated by N flag of IS-IS Prefix SID flags or by N flag of Prefix Attribute Flags.
+func (p *PrefixAttrTLVs) IsNodeSID() bool {
+	if len(p.LSPrefixSID) == 0 {
+		return false
+	}
+	for _, ps := range p.LSPrefixSID {
+		if ps.IsNodeSID() {
+			return true
+		}
+	}
+	switch f := p.Flags.(type) {
+	case *ISISFlags:
+		return f.NFlag
+	case *OSPFFlags:
+		return f.NFlag
+	case *OSPFv3Flags:
+		return f.NFlag
+	}
+
+	return false
+}
+
 // PrefixAttrFlags defines Prefix Attribute Flags interface
 type PrefixAttrFlags interface {
 	GetPrefixAttrFlagsByte() byte
@@ -249,7 +272,7 @@ func (f *OSPFFlags) GetPrefixAttrFlagsByte() byte {
 		b += 0x80
 	}
 	if f.NFlag {
-		b += 0x20
+		b += 0x40
 	}
 
 	return b
@@ -2,6 +2,7 @@ package bmp
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/golang/glog"
@@ -12,6 +13,46 @@ const (
 	BMP_HEADER_SIZE = 6
 )
 
+// ErrUnsupportedBMPVersion is matched by UnsupportedVersionError with errors.Is
+var ErrUnsupportedBMPVersion = errors.New("unsupported bmp version")
+
+// UnsupportedVersionError is returned when Common Header carries a version other than 3, Version and
+// MessageLength are recovered from the header, so the caller can skip the message. MessageLength
+// is meaningful only for versions above 3 which are expected to keep version 3 header format.
+type UnsupportedVersionError struct {
+	Version       byte
+	MessageLength int32
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("invalid version in common header, expected 3 found %d", e.Version)
+}
+
+// Unwrap returns ErrUnsupportedBMPVersion
+func (e *UnsupportedVersionError) Unwrap() error {
+	return ErrUnsupportedBMPVersion
+}
+
+// ErrLengthMismatch is matched by LengthMismatchError with errors.Is
+var ErrLengthMismatch = errors.New("bmp message length mismatch")
+
+// LengthMismatchError is returned when Message Length of Common Header does not match the number of bytes
+// available for the message, both values include Common Header.
+type LengthMismatchError struct {
+	MessageType   byte
+	MessageLength int32
+	Available     int
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("message of type %d length %d in common header does not match %d available bytes", e.MessageType, e.MessageLength, e.Available)
+}
+
+// Unwrap returns ErrLengthMismatch
+func (e *LengthMismatchError) Unwrap() error {
+	return ErrLengthMismatch
+}
+
 // CommonHeader defines BMP message Common Header per rfc7854
 type CommonHeader struct {
 	Version       byte
@@ -24,9 +65,15 @@ func UnmarshalCommonHeader(b []byte) (*CommonHeader, error) {
 	if glog.V(6) {
 		glog.Infof("BMP CommonHeader Raw: %s", tools.MessageHex(b))
 	}
+	if len(b) < BMP_HEADER_SIZE {
+		return nil, fmt.Errorf("not enough bytes to unmarshal common header")
+	}
 	ch := &CommonHeader{}
 	if b[0] != 3 {
-		return nil, fmt.Errorf("invalid version in common header, expected 3 found %d", b[0])
+		return nil, &UnsupportedVersionError{
+			Version:       b[0],
+			MessageLength: int32(binary.BigEndian.Uint32(b[1:5])),
+		}
 	}
 	ch.Version = b[0]
 	ch.MessageLength = int32(binary.BigEndian.Uint32(b[1:5]))
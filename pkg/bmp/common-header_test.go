@@ -1,6 +1,7 @@
 package bmp
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -51,3 +52,34 @@ func TestCommonHeaderRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestCommonHeaderUnsupportedVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		version byte
+		length  int32
+	}{
+		{
+			name:    "version 4",
+			input:   []byte{4, 0, 0, 0, 0x40, 0},
+			version: 4,
+			length:  64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalCommonHeader(tt.input)
+			if !errors.Is(err, ErrUnsupportedBMPVersion) {
+				t.Fatalf("expected ErrUnsupportedBMPVersion, got: %+v", err)
+			}
+			var vErr *UnsupportedVersionError
+			if !errors.As(err, &vErr) {
+				t.Fatalf("expected UnsupportedVersionError, got: %T", err)
+			}
+			if vErr.Version != tt.version || vErr.MessageLength != tt.length {
+				t.Fatalf("expected version %d length %d, got version %d length %d", tt.version, tt.length, vErr.Version, vErr.MessageLength)
+			}
+		})
+	}
+}
@@ -8,9 +8,23 @@ import (
 	"github.com/sbezverk/tools"
 )
 
-// InitiationMessage defines BMP Initiation Message per rfc7854
+// InitiationEnterpriseTLVMin defines the first of Initiation TLV types reserved for enterprise specific
+// and experimental use, types 65531 to 65535.
+const InitiationEnterpriseTLVMin = 65531
+
+// InitiationMessage defines BMP Initiation Message per rfc7854, VendorTLVs carries enterprise specific
+// TLVs in raw form, the vendor data format is not standardized.
 type InitiationMessage struct {
-	TLV []InformationalTLV
+	TLV        []InformationalTLV
+	VendorTLVs []VendorTLV
+}
+
+// VendorTLV defines enterprise specific Initiation TLV, type and length are 2 bytes unsigned integers
+// as in rfc7854 Information TLV format.
+type VendorTLV struct {
+	InformationType   uint16
+	InformationLength uint16
+	Information       []byte
 }
 
 // UnmarshalInitiationMessage processes Initiation Message and returns BMPInitiationMessage object
@@ -22,27 +36,42 @@ func UnmarshalInitiationMessage(b []byte) (*InitiationMessage, error) {
 		TLV: make([]InformationalTLV, 0),
 	}
 	for i := 0; i < len(b); {
+		if i+4 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal initiation tlv")
+		}
 		// Extracting TLV type 2 bytes
-		t := int16(binary.BigEndian.Uint16(b[i : i+2]))
-		switch t {
-		case 0:
-		case 1:
-		case 2:
+		tt := binary.BigEndian.Uint16(b[i : i+2])
+		vendor := tt >= InitiationEnterpriseTLVMin
+		switch {
+		case tt <= 2:
+		case vendor:
 		default:
-			return nil, fmt.Errorf("invalid tlv type, expected between 0 and 2 found %d", t)
+			return nil, fmt.Errorf("invalid tlv type, expected between 0 and 2 found %d", tt)
 		}
 		// Extracting TLV length
-		l := int16(binary.BigEndian.Uint16(b[i+2 : i+4]))
-		if l > int16(len(b)-(i+4)) {
-			return nil, fmt.Errorf("invalid tlv length %d", l)
+		tl := binary.BigEndian.Uint16(b[i+2 : i+4])
+		if int(tl) > len(b)-(i+4) {
+			return nil, fmt.Errorf("invalid tlv length %d", tl)
+		}
+		v := b[i+4 : i+4+int(tl)]
+		i += 4 + int(tl)
+		if vendor {
+			im.VendorTLVs = append(im.VendorTLVs, VendorTLV{
+				InformationType:   tt,
+				InformationLength: tl,
+				Information:       v,
+			})
+			continue
+		}
+		l := int16(tl)
+		if l < 0 {
+			return nil, fmt.Errorf("invalid tlv length %d", tl)
 		}
-		v := b[i+4 : i+4+int(l)]
 		im.TLV = append(im.TLV, InformationalTLV{
-			InformationType:   t,
+			InformationType:   int16(tt),
 			InformationLength: l,
 			Information:       v,
 		})
-		i += 4 + int(l)
 	}
 
 	return im, nil
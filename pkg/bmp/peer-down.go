@@ -7,10 +7,32 @@ import (
 	"github.com/sbezverk/tools"
 )
 
+const (
+	// PeerDownLocalSystemClosedTLV defines Peer Down reason code 6, the local system closed the session
+	// and the data carries Information TLVs, RFC 9069 section 5.4
+	PeerDownLocalSystemClosedTLV = 6
+	// VRFTableNameTLV defines VRF/Table Name Information TLV type, RFC 9069 section 5.1
+	VRFTableNameTLV = 3
+)
+
 // PeerDownMessage defines BMPPeerDownMessage per rfc7854
 type PeerDownMessage struct {
 	Reason uint8
 	Data   []byte
+	// Information carries Information TLVs found in Data of reason code 6, nil for other reason codes
+	Information []InformationalTLV
+}
+
+// GetTableName returns the value of VRF/Table Name TLV and true, if Peer Down message does not carry
+// VRF/Table Name TLV, false is returned.
+func (pdw *PeerDownMessage) GetTableName() (string, bool) {
+	for _, tlv := range pdw.Information {
+		if tlv.InformationType == VRFTableNameTLV {
+			return string(tlv.Information), true
+		}
+	}
+
+	return "", false
 }
 
 // UnmarshalPeerDownMessage processes Peer Down message and returns BMPPeerDownMessage object
@@ -18,16 +40,26 @@ func UnmarshalPeerDownMessage(b []byte) (*PeerDownMessage, error) {
 	if glog.V(6) {
 		glog.Infof("BMP Peer Down Message Raw: %s", tools.MessageHex(b))
 	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("not enough bytes to unmarshal Peer Down message")
+	}
 	pdw := &PeerDownMessage{
 		Data: make([]byte, len(b)-1),
 	}
 	p := 0
 	pdw.Reason = b[p]
 	p++
-	if pdw.Reason < 1 || pdw.Reason > 5 {
+	if pdw.Reason < 1 || pdw.Reason > PeerDownLocalSystemClosedTLV {
 		return nil, fmt.Errorf("invalid reason code %d in Peer Down message", pdw.Reason)
 	}
 	copy(pdw.Data, b[p:])
+	if pdw.Reason == PeerDownLocalSystemClosedTLV {
+		tlvs, err := UnmarshalTLV(pdw.Data)
+		if err != nil {
+			return nil, err
+		}
+		pdw.Information = tlvs
+	}
 
 	return pdw, nil
 }
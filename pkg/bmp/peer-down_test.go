@@ -7,9 +7,10 @@ import (
 
 func TestPeerDownMsg(t *testing.T) {
 	tests := []struct {
-		name   string
-		input  []byte
-		expect *PeerDownMessage
+		name      string
+		input     []byte
+		expect    *PeerDownMessage
+		tableName string
 	}{
 		{
 			name:  "real case 1",
@@ -19,6 +20,22 @@ func TestPeerDownMsg(t *testing.T) {
 				Data:   []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x15, 0x03, 0x06, 0x04},
 			},
 		},
+		{
+			name:  "local system closed with vrf table name tlv",
+			input: []byte{0x06, 0x00, 0x03, 0x00, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c},
+			expect: &PeerDownMessage{
+				Reason: 6,
+				Data:   []byte{0x00, 0x03, 0x00, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c},
+				Information: []InformationalTLV{
+					{
+						InformationType:   3,
+						InformationLength: 6,
+						Information:       []byte{0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c},
+					},
+				},
+			},
+			tableName: "global",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -29,6 +46,36 @@ func TestPeerDownMsg(t *testing.T) {
 			if !reflect.DeepEqual(tt.expect, peerDown) {
 				t.Fatalf("expected %+v does not match unmarshaled %+v", tt.expect, peerDown)
 			}
+			if n, _ := peerDown.GetTableName(); n != tt.tableName {
+				t.Fatalf("expected table name %q, got %q", tt.tableName, n)
+			}
+		})
+	}
+}
+
+func TestPeerDownMsgMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "empty message",
+			input: []byte{},
+		},
+		{
+			name:  "invalid reason code",
+			input: []byte{0x07},
+		},
+		{
+			name:  "local system closed with truncated tlv",
+			input: []byte{0x06, 0x00, 0x03, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := UnmarshalPeerDownMessage(tt.input); err == nil {
+				t.Fatal("supposed to fail but succeeded")
+			}
 		})
 	}
 }
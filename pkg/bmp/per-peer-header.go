@@ -51,6 +51,9 @@ type PerPeerHeader struct {
 	PeerAS            uint32
 	PeerBGPID         []byte
 	PeerTimestamp     []byte
+	// VRFRD is the Route Distinguisher of the VRF for RD Instance Peer (Peer Type 1),
+	// nil for other peer types or if the distinguisher is not a valid RD.
+	VRFRD *base.RD
 }
 
 // Len returns the length of PerPeerHeader structure
@@ -122,6 +125,12 @@ func (p *PerPeerHeader) IsAdjRIBOutPost() (bool, error) {
 	return false, ErrInvFlagRequestForPeerType
 }
 
+// IsEBGP returns true if Peer AS differs from the monitored router's local AS, meaning routes
+// were learned over eBGP session. Local AS 0 means the local AS is not known and false is returned.
+func (p *PerPeerHeader) IsEBGP(localAS uint32) bool {
+	return localAS != 0 && p.PeerAS != localAS
+}
+
 // IsAdjRIBInPost returns true if PeerType is 0,1 or 2 and L flag is set, otherwise it returns error
 func (p *PerPeerHeader) IsAdjRIBInPost() (bool, error) {
 	if p.PeerType != PeerType3 {
@@ -140,6 +149,49 @@ func (p *PerPeerHeader) IsLocRIBFiltered() (bool, error) {
 	return false, ErrInvFlagRequestForPeerType
 }
 
+// PeerFlags defines Per-Peer Header flags, the meaning of the flags' byte depends on the peer type.
+// For Peer Type 0, 1 and 2 the flags are defined by rfc7854 and rfc8671:
+//
+//	 0 1 2 3 4 5 6 7
+//	+-+-+-+-+-+-+-+-+
+//	|V|L|A|O| Resv  |
+//	+-+-+-+-+-+-+-+-+
+//
+// For Loc-RIB Instance Peer, Peer Type 3, the only flag is defined by rfc9069:
+//
+//	 0 1 2 3 4 5 6 7
+//	+-+-+-+-+-+-+-+-+
+//	|F|  Reserved   |
+//	+-+-+-+-+-+-+-+-+
+//
+// Since F flag occupies the same bit as V flag, only the flags applicable to the peer type are set.
+type PeerFlags struct {
+	// IPv6 is V flag, peer address is IPv6
+	IPv6 bool
+	// PostPolicy is L flag, Adj-RIB-In or Adj-RIB-Out is post-policy
+	PostPolicy bool
+	// LegacyASPath is A flag, AS_PATH is in 2 byte AS format
+	LegacyASPath bool
+	// AdjRIBOut is O flag, the message carries Adj-RIB-Out
+	AdjRIBOut bool
+	// Filtered is F flag, Loc-RIB is filtered
+	Filtered bool
+}
+
+// GetPeerFlags returns flags of Per-Peer Header decoded according to the peer type
+func (p *PerPeerHeader) GetPeerFlags() PeerFlags {
+	if p.PeerType == PeerType3 {
+		return PeerFlags{Filtered: p.flagF}
+	}
+
+	return PeerFlags{
+		IPv6:         p.flagV,
+		PostPolicy:   p.flagL,
+		LegacyASPath: p.flagA,
+		AdjRIBOut:    p.flagO,
+	}
+}
+
 // IsRemotePeerIPv6 returns true if Remote Peer is IPv6 for PeerType is 0,1 or 2, for Peer Type 3 always returns false.
 func (p *PerPeerHeader) IsRemotePeerIPv6() bool {
 	if p.PeerType != PeerType3 {
@@ -201,6 +253,11 @@ func UnmarshalPerPeerHeader(b []byte) (*PerPeerHeader, error) {
 	p++
 	// RD 8 bytes
 	copy(pph.PeerDistinguisher, b[p:p+8])
+	if pph.PeerType == PeerType1 {
+		if rd, err := base.MakeRD(pph.PeerDistinguisher); err == nil {
+			pph.VRFRD = rd
+		}
+	}
 	p += 8
 	// Peer Address 16 bytes but for IPv4 case only last 4 bytes needed
 	copy(pph.PeerAddress, b[p:p+16])
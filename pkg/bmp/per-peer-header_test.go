@@ -0,0 +1,119 @@
+package bmp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/base"
+)
+
+func TestPerPeerHeaderVRFRD(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *base.RD
+		rd     string
+	}{
+		{
+			name: "rd instance peer with type 0 rd",
+			input: []byte{
+				0x01, 0x00,
+				0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0x00, 0x64,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0xa8, 0x01, 0x01,
+				0x00, 0x00, 0xfd, 0xea,
+				0xc0, 0xa8, 0x01, 0x01,
+				0x5e, 0x5f, 0x6f, 0x1a, 0x00, 0x00, 0x00, 0x00,
+			},
+			expect: &base.RD{Type: 0, Value: []byte{0xfd, 0xe9, 0x00, 0x00, 0x00, 0x64}},
+			rd:     "65001:100",
+		},
+		{
+			name: "rd instance peer with type 1 rd",
+			input: []byte{
+				0x01, 0x00,
+				0x00, 0x01, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x07,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0xa8, 0x01, 0x01,
+				0x00, 0x00, 0xfd, 0xea,
+				0xc0, 0xa8, 0x01, 0x01,
+				0x5e, 0x5f, 0x6f, 0x1a, 0x00, 0x00, 0x00, 0x00,
+			},
+			expect: &base.RD{Type: 1, Value: []byte{0x0a, 0x00, 0x00, 0x01, 0x00, 0x07}},
+			rd:     "10.0.0.1:7",
+		},
+		{
+			name: "global instance peer",
+			input: []byte{
+				0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0xa8, 0x01, 0x01,
+				0x00, 0x00, 0xfd, 0xea,
+				0xc0, 0xa8, 0x01, 0x01,
+				0x5e, 0x5f, 0x6f, 0x1a, 0x00, 0x00, 0x00, 0x00,
+			},
+			expect: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph, err := UnmarshalPerPeerHeader(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal per peer header with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, ph.VRFRD) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, ph.VRFRD))
+				t.Fatal("the expected vrf rd does not match the actual")
+			}
+			if ph.VRFRD != nil && ph.VRFRD.String() != tt.rd {
+				t.Fatalf("expected vrf rd %s, got %s", tt.rd, ph.VRFRD.String())
+			}
+		})
+	}
+}
+
+func TestPerPeerHeaderFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect PeerFlags
+	}{
+		{
+			name:   "rfc7854 global instance peer v l a flags",
+			input:  []byte{0x00, 0xe0},
+			expect: PeerFlags{IPv6: true, PostPolicy: true, LegacyASPath: true},
+		},
+		{
+			name:   "rfc8671 adj-rib-out post-policy",
+			input:  []byte{0x00, 0x50},
+			expect: PeerFlags{PostPolicy: true, AdjRIBOut: true},
+		},
+		{
+			name:   "rfc8671 rd instance peer adj-rib-out pre-policy",
+			input:  []byte{0x01, 0x10},
+			expect: PeerFlags{AdjRIBOut: true},
+		},
+		{
+			name:   "rfc9069 loc-rib filtered",
+			input:  []byte{0x03, 0x80},
+			expect: PeerFlags{Filtered: true},
+		},
+		{
+			name:   "rfc9069 loc-rib ignores v l a o bits",
+			input:  []byte{0x03, 0x70},
+			expect: PeerFlags{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := make([]byte, BMP_PEER_HEADER_SIZE)
+			copy(b, tt.input)
+			ph, err := UnmarshalPerPeerHeader(b)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			if got := ph.GetPeerFlags(); !reflect.DeepEqual(tt.expect, got) {
+				t.Errorf("expected flags %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package bmp
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/tools"
+)
+
+const (
+	// RouteMirroringBGPMessageTLV defines Route Mirroring TLV type carrying a BGP PDU, RFC 7854 section 4.7
+	RouteMirroringBGPMessageTLV = 0
+	// RouteMirroringInformationTLV defines Route Mirroring TLV type carrying a 2 bytes information code,
+	// RFC 7854 section 4.7
+	RouteMirroringInformationTLV = 1
+)
+
+const (
+	// MirroringErroredPDU defines Information code signaling the mirrored BGP PDU was errored,
+	// the contained message is the errored PDU
+	MirroringErroredPDU = 0
+	// MirroringMessagesLost defines Information code signaling one or more messages were lost and
+	// could not be mirrored
+	MirroringMessagesLost = 1
+)
+
+// RouteMirroringMessage defines BMP Route Mirroring Message per rfc7854, BGPMessages carries BGP PDUs
+// of BGP Message TLVs and InformationCodes carries codes of Information TLVs in the order they were found.
+type RouteMirroringMessage struct {
+	TLV              []InformationalTLV
+	BGPMessages      [][]byte
+	InformationCodes []uint16
+	// ErroredPDU is set when the message carries Errored PDU information code
+	ErroredPDU bool
+	// MessagesLost is set when the message carries Messages Lost information code, the router
+	// dropped messages it was supposed to mirror
+	MessagesLost bool
+}
+
+// UnmarshalRouteMirroringMessage processes Route Mirroring message and returns RouteMirroringMessage object
+func UnmarshalRouteMirroringMessage(b []byte) (*RouteMirroringMessage, error) {
+	if glog.V(6) {
+		glog.Infof("BMP Route Mirroring Message Raw: %s", tools.MessageHex(b))
+	}
+	p := make([]byte, len(b))
+	copy(p, b)
+	tlvs, err := UnmarshalTLV(p)
+	if err != nil {
+		return nil, err
+	}
+	rm := &RouteMirroringMessage{
+		TLV: tlvs,
+	}
+	for _, tlv := range tlvs {
+		switch tlv.InformationType {
+		case RouteMirroringBGPMessageTLV:
+			rm.BGPMessages = append(rm.BGPMessages, tlv.Information)
+		case RouteMirroringInformationTLV:
+			if len(tlv.Information) != 2 {
+				return nil, fmt.Errorf("invalid route mirroring information tlv length %d", len(tlv.Information))
+			}
+			code := binary.BigEndian.Uint16(tlv.Information)
+			rm.InformationCodes = append(rm.InformationCodes, code)
+			switch code {
+			case MirroringErroredPDU:
+				rm.ErroredPDU = true
+			case MirroringMessagesLost:
+				rm.MessagesLost = true
+			}
+		}
+	}
+
+	return rm, nil
+}
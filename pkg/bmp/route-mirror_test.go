@@ -0,0 +1,75 @@
+package bmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRouteMirroringMsg(t *testing.T) {
+	keepalive := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x13, 0x04}
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *RouteMirroringMessage
+		fail   bool
+	}{
+		{
+			name:  "messages lost",
+			input: []byte{0x00, 0x01, 0x00, 0x02, 0x00, 0x01},
+			expect: &RouteMirroringMessage{
+				TLV: []InformationalTLV{
+					{
+						InformationType:   1,
+						InformationLength: 2,
+						Information:       []byte{0x00, 0x01},
+					},
+				},
+				InformationCodes: []uint16{MirroringMessagesLost},
+				MessagesLost:     true,
+			},
+		},
+		{
+			name:  "errored pdu",
+			input: append([]byte{0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x13}, keepalive...),
+			expect: &RouteMirroringMessage{
+				TLV: []InformationalTLV{
+					{
+						InformationType:   1,
+						InformationLength: 2,
+						Information:       []byte{0x00, 0x00},
+					},
+					{
+						InformationType:   0,
+						InformationLength: 19,
+						Information:       keepalive,
+					},
+				},
+				BGPMessages:      [][]byte{keepalive},
+				InformationCodes: []uint16{MirroringErroredPDU},
+				ErroredPDU:       true,
+			},
+		},
+		{
+			name:  "invalid information tlv length",
+			input: []byte{0x00, 0x01, 0x00, 0x01, 0x01},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, err := UnmarshalRouteMirroringMessage(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("failed but supposed to succeed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, rm) {
+				t.Fatalf("expected %+v does not match unmarshaled %+v", tt.expect, rm)
+			}
+		})
+	}
+}
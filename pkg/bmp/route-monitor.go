@@ -1,7 +1,9 @@
 package bmp
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net/netip"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/gobmp/pkg/bgp"
@@ -11,10 +13,29 @@ import (
 // RouteMonitor defines a structure of BMP Route Monitoring message
 type RouteMonitor struct {
 	Update *bgp.Update
+	// TLV carries optional TLVs following BGP Update PDU, draft-ietf-grow-bmp-tlv, the end of the PDU
+	// is found by BGP message length, nil when the message does not carry TLVs.
+	TLV []InformationalTLV
+	// BGPMessageLength is the length of embedded BGP Update message including its 19 bytes header
+	BGPMessageLength uint16
+}
+
+var bgpMessageTypeNames = map[uint8]string{
+	1: "OPEN",
+	2: "UPDATE",
+	3: "NOTIFICATION",
+	4: "KEEPALIVE",
+	5: "ROUTE-REFRESH",
 }
 
 // UnmarshalBMPRouteMonitorMessage builds BMP Route Monitor object
 func UnmarshalBMPRouteMonitorMessage(b []byte) (*RouteMonitor, error) {
+	return UnmarshalBMPRouteMonitorMessageWithContext(b, nil)
+}
+
+// UnmarshalBMPRouteMonitorMessageWithContext builds BMP Route Monitor object decoding BGP Update
+// with parameters negotiated for the monitored peer's session, ctx can be nil if they are not known.
+func UnmarshalBMPRouteMonitorMessageWithContext(b []byte, ctx *bgp.SessionContext) (*RouteMonitor, error) {
 	if glog.V(6) {
 		glog.Infof("BMP Route Monitor Message Raw: %s length: %d", tools.MessageHex(b), len(b))
 	}
@@ -26,21 +47,84 @@ func UnmarshalBMPRouteMonitorMessage(b []byte) (*RouteMonitor, error) {
 	p := 0
 	// Skip 16 bytes of a marker
 	p += 16
-	// Skip 2 bytes of the update length
+	// BGP message length includes the marker, the length and the type
+	l := int(binary.BigEndian.Uint16(b[p : p+2]))
+	if l < 19 || l > len(b) {
+		return nil, fmt.Errorf("invalid bgp message length %d in route monitor message of %d bytes", l, len(b))
+	}
 	p += 2
-	// Getting update type, currently only type 2 is processed
-	t := b[p]
+	rm.BGPMessageLength = uint16(l)
+	// Route Monitoring message must carry BGP Update, other types of messages may be found in captures of
+	// misconfigured mirroring
+	if t := b[p]; t != 2 {
+		n, ok := bgpMessageTypeNames[t]
+		if !ok {
+			n = "unknown"
+		}
+		return nil, fmt.Errorf("route monitor message carries bgp message of type %d (%s) instead of update", t, n)
+	}
 	p++
-	switch t {
-	case 2:
-		// Update type
-		u, err := bgp.UnmarshalBGPUpdate(b[p:])
+	u, err := bgp.UnmarshalBGPUpdateWithContext(b[p:l], ctx)
+	if err != nil {
+		return nil, err
+	}
+	rm.Update = u
+	if l < len(b) {
+		tlvs, err := UnmarshalTLV(b[l:])
 		if err != nil {
 			return nil, err
 		}
-		rm.Update = u
-	default:
+		rm.TLV = tlvs
 	}
 
 	return &rm, nil
 }
+
+// GetRIBEvents returns RIB events found in Route Monitoring message's BGP Update, each event is tagged
+// as Adj-RIB-In post-policy when L flag is set in the message's Per-Peer Header.
+func (rm *RouteMonitor) GetRIBEvents(ph *PerPeerHeader, addPath map[int]bool) ([]bgp.RIBEvent, error) {
+	return rm.GetRIBEventsWithContext(ph, addPath, nil)
+}
+
+// GetRIBEventsWithContext returns RIB events as GetRIBEvents does, additionally each event is tagged as
+// eBGP learned when the monitored router's local AS recovered from its Open message differs from
+// the Peer AS of the message's Per-Peer Header, ctx can be nil if the session is not known.
+func (rm *RouteMonitor) GetRIBEventsWithContext(ph *PerPeerHeader, addPath map[int]bool, ctx *bgp.SessionContext) ([]bgp.RIBEvent, error) {
+	if rm.Update == nil {
+		return nil, fmt.Errorf("route monitor message does not carry bgp update")
+	}
+	events, err := rm.Update.GetRIBEvents(addPath)
+	if err != nil {
+		return nil, err
+	}
+	// Loc-RIB peers do not carry L flag, the error is ignored and events are left as pre-policy
+	post, _ := ph.IsAdjRIBInPost()
+	ebgp := ctx != nil && ph.IsEBGP(ctx.LocalAS)
+	for i := range events {
+		events[i].PostPolicy = post
+		events[i].IsEBGP = ebgp
+	}
+
+	return events, nil
+}
+
+// GetRIBEntries returns RIB entries of routes announced by Route Monitoring message's BGP Update, entries are
+// attributed with the peer of the message's Per-Peer Header, eBGP is determined as GetRIBEventsWithContext
+// does, ctx can be nil if the session is not known.
+func (rm *RouteMonitor) GetRIBEntries(ph *PerPeerHeader, addPath map[int]bool, ctx *bgp.SessionContext) ([]bgp.RIBEntry, error) {
+	if rm.Update == nil {
+		return nil, fmt.Errorf("route monitor message does not carry bgp update")
+	}
+	peer := &bgp.PeerContext{
+		PeerAS:  ph.PeerAS,
+		IsEBGP:  ctx != nil && ph.IsEBGP(ctx.LocalAS),
+		AddPath: addPath,
+	}
+	if addr, err := netip.ParseAddr(ph.GetPeerAddrString()); err == nil {
+		peer.PeerAddr = addr
+	}
+	// Loc-RIB peers do not carry L flag, the error is ignored and entries are left as pre-policy
+	peer.PostPolicy, _ = ph.IsAdjRIBInPost()
+
+	return rm.Update.GetRIBEntries(peer)
+}
@@ -0,0 +1,340 @@
+package bmp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/bgp"
+)
+
+func TestRouteMonitorRIBEvents(t *testing.T) {
+	// BGP Update announcing 10.10.10.0/24 with next hop 10.0.0.1
+	update := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x2f, 0x02,
+		0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xfd, 0xe9, 0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+	}
+	peerHeader := func(flags byte) []byte {
+		return []byte{
+			0x00, flags, 0, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 192, 168, 80, 103,
+			0x00, 0x00, 0xfd, 0xe9,
+			192, 168, 80, 103,
+			0, 0, 0, 0, 0, 0, 0, 0,
+		}
+	}
+	// Peer AS in the per-peer header is 65001
+	session := func(localAS uint16) *bgp.SessionContext {
+		return bgp.NewSessionContext(&bgp.OpenMessage{MyAS: localAS}, &bgp.OpenMessage{MyAS: 65001})
+	}
+	tests := []struct {
+		name   string
+		input  []byte
+		ctx    *bgp.SessionContext
+		expect []bgp.RIBEvent
+	}{
+		{
+			name:  "pre-policy",
+			input: append(peerHeader(0x00), update...),
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:  bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop: "10.0.0.1",
+				},
+			},
+		},
+		{
+			name:  "post-policy",
+			input: append(peerHeader(0x40), update...),
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:     bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop:    "10.0.0.1",
+					PostPolicy: true,
+				},
+			},
+		},
+		{
+			name:  "ebgp session",
+			input: append(peerHeader(0x00), update...),
+			ctx:   session(65000),
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:  bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop: "10.0.0.1",
+					IsEBGP:  true,
+				},
+			},
+		},
+		{
+			name:  "ibgp session",
+			input: append(peerHeader(0x00), update...),
+			ctx:   session(65001),
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:  bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop: "10.0.0.1",
+				},
+			},
+		},
+		{
+			name:  "session without open messages",
+			input: append(peerHeader(0x00), update...),
+			ctx:   bgp.NewSessionContext(nil, nil),
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:  bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					NextHop: "10.0.0.1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalBMPMessage(&CommonHeader{Version: 3, MessageType: RouteMonitorMsg, MessageLength: int32(CommonHeaderLength + len(tt.input))}, tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal route monitor message with error: %+v", err)
+			}
+			rm, ok := msg.Payload.(*RouteMonitor)
+			if !ok {
+				t.Fatalf("expected route monitor payload, got %T", msg.Payload)
+			}
+			got, err := rm.GetRIBEventsWithContext(msg.PeerHeader, nil, tt.ctx)
+			if err != nil {
+				t.Fatalf("failed to get rib events with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected events do not match the actual")
+			}
+		})
+	}
+}
+
+func TestRouteMonitorWithdrawAndEndOfRIB(t *testing.T) {
+	peerHeader := []byte{
+		0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 192, 168, 80, 103,
+		0x00, 0x00, 0xfd, 0xe9,
+		192, 168, 80, 103,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	marker := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	tests := []struct {
+		name   string
+		update []byte
+		expect []bgp.RIBEvent
+		eor    bool
+		afi    bgp.AFISAFI
+	}{
+		{
+			name: "ipv6 withdraw only",
+			// MP_UNREACH_NLRI AFI 2 SAFI 1 withdrawing 2001:db8::/32
+			update: []byte{0x00, 0x23, 0x02, 0x00, 0x00, 0x00, 0x0c, 0x90, 0x0f, 0x00, 0x08, 0x00, 0x02, 0x01, 0x20, 0x20, 0x01, 0x0d, 0xb8},
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:   bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 2, SAFI: 1}, Prefix: netip.MustParsePrefix("2001:db8::/32")},
+					Withdraw: true,
+				},
+			},
+		},
+		{
+			name: "ipv4 withdraw only",
+			// Withdrawn Routes 10.10.10.0/24
+			update: []byte{0x00, 0x1b, 0x02, 0x00, 0x04, 0x18, 0x0a, 0x0a, 0x0a, 0x00, 0x00},
+			expect: []bgp.RIBEvent{
+				{
+					Prefix:   bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+					Withdraw: true,
+				},
+			},
+		},
+		{
+			name:   "ipv6 end of rib",
+			update: []byte{0x00, 0x1d, 0x02, 0x00, 0x00, 0x00, 0x06, 0x80, 0x0f, 0x03, 0x00, 0x02, 0x01},
+			expect: []bgp.RIBEvent{},
+			eor:    true,
+			afi:    bgp.AFISAFI{AFI: 2, SAFI: 1},
+		},
+		{
+			name:   "ipv4 end of rib",
+			update: []byte{0x00, 0x17, 0x02, 0x00, 0x00, 0x00, 0x00},
+			expect: []bgp.RIBEvent{},
+			eor:    true,
+			afi:    bgp.AFISAFI{AFI: 1, SAFI: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append(append(append([]byte{}, peerHeader...), marker...), tt.update...)
+			msg, err := UnmarshalBMPMessage(&CommonHeader{Version: 3, MessageType: RouteMonitorMsg, MessageLength: int32(CommonHeaderLength + len(input))}, input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal route monitor message with error: %+v", err)
+			}
+			rm, ok := msg.Payload.(*RouteMonitor)
+			if !ok {
+				t.Fatalf("expected route monitor payload, got %T", msg.Payload)
+			}
+			got, err := rm.GetRIBEvents(msg.PeerHeader, nil)
+			if err != nil {
+				t.Fatalf("failed to get rib events with error: %+v", err)
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected events do not match the actual")
+			}
+			afi, eor := rm.Update.IsEndOfRIB()
+			if eor != tt.eor || afi != tt.afi {
+				t.Fatalf("expected end of rib %t for %+v, got %t for %+v", tt.eor, tt.afi, eor, afi)
+			}
+		})
+	}
+}
+
+func TestRouteMonitorTrailingTLV(t *testing.T) {
+	// BGP Update announcing 10.10.10.0/24 with next hop 10.0.0.1
+	update := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x2f, 0x02,
+		0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xfd, 0xe9, 0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+	}
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []InformationalTLV
+		fail   bool
+	}{
+		{
+			name:  "without tlv",
+			input: update,
+		},
+		{
+			name:  "with trailing tlv",
+			input: append(append([]byte{}, update...), 0x00, 0x00, 0x00, 0x04, 0x76, 0x72, 0x66, 0x31),
+			expect: []InformationalTLV{
+				{
+					InformationType:   0,
+					InformationLength: 4,
+					Information:       []byte{0x76, 0x72, 0x66, 0x31},
+				},
+			},
+		},
+		{
+			name:  "truncated trailing tlv",
+			input: append(append([]byte{}, update...), 0x00, 0x00, 0x00),
+			fail:  true,
+		},
+		{
+			name:  "bgp message length exceeds route monitor message",
+			input: update[:len(update)-1],
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, err := UnmarshalBMPRouteMonitorMessage(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("failed to unmarshal route monitor message with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, rm.TLV) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, rm.TLV))
+				t.Fatal("the expected tlvs do not match the actual")
+			}
+			// TLV must not be decoded as a part of Update's NLRI
+			if nlri := []byte{0x18, 0x0a, 0x0a, 0x0a}; !reflect.DeepEqual(nlri, rm.Update.NLRI) {
+				t.Fatalf("expected nlri %v, got %v", nlri, rm.Update.NLRI)
+			}
+		})
+	}
+}
+
+func TestRouteMonitorBGPMessageType(t *testing.T) {
+	marker := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	tests := []struct {
+		name   string
+		input  []byte
+		length uint16
+		fail   bool
+	}{
+		{
+			name:   "update",
+			input:  append(append([]byte{}, marker...), 0x00, 0x17, 0x02, 0x00, 0x00, 0x00, 0x00),
+			length: 23,
+		},
+		{
+			name:  "keepalive",
+			input: append(append([]byte{}, marker...), 0x00, 0x13, 0x04),
+			fail:  true,
+		},
+		{
+			name:  "notification cease",
+			input: append(append([]byte{}, marker...), 0x00, 0x15, 0x03, 0x06, 0x02),
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, err := UnmarshalBMPRouteMonitorMessage(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("failed to unmarshal route monitor message with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if rm.BGPMessageLength != tt.length {
+				t.Fatalf("expected bgp message length %d, got %d", tt.length, rm.BGPMessageLength)
+			}
+		})
+	}
+}
+
+func TestRouteMonitorRIBEntries(t *testing.T) {
+	// Post-policy per-peer header of peer 192.168.80.103 in AS 65001 followed by BGP Update announcing
+	// 10.10.10.0/24 with next hop 10.0.0.1
+	input := []byte{
+		0x00, 0x40, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 192, 168, 80, 103,
+		0x00, 0x00, 0xfd, 0xe9,
+		192, 168, 80, 103,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x2f, 0x02,
+		0x00, 0x00, 0x00, 0x14, 0x40, 0x01, 0x01, 0x00, 0x40, 0x02, 0x06, 0x02, 0x01, 0x00, 0x00, 0xfd, 0xe9, 0x40, 0x03, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x18, 0x0a, 0x0a, 0x0a,
+	}
+	msg, err := UnmarshalBMPMessage(&CommonHeader{Version: 3, MessageType: RouteMonitorMsg, MessageLength: int32(CommonHeaderLength + len(input))}, input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal route monitor message with error: %+v", err)
+	}
+	rm, ok := msg.Payload.(*RouteMonitor)
+	if !ok {
+		t.Fatalf("expected route monitor payload, got %T", msg.Payload)
+	}
+	ctx := bgp.NewSessionContext(&bgp.OpenMessage{MyAS: 65000}, &bgp.OpenMessage{MyAS: 65001})
+	got, err := rm.GetRIBEntries(msg.PeerHeader, nil, ctx)
+	if err != nil {
+		t.Fatalf("failed to get rib entries with error: %+v", err)
+	}
+	expect := []bgp.RIBEntry{
+		{
+			Prefix:     bgp.Prefix{AFISAFI: bgp.AFISAFI{AFI: 1, SAFI: 1}, Prefix: netip.MustParsePrefix("10.10.10.0/24")},
+			NextHop:    netip.MustParseAddr("10.0.0.1"),
+			Origin:     "igp",
+			ASPath:     []uint32{65001},
+			PeerAddr:   netip.MustParseAddr("192.168.80.103"),
+			PeerAS:     65001,
+			PostPolicy: true,
+			IsEBGP:     true,
+		},
+	}
+	if diffs := deep.Equal(expect, got); len(diffs) != 0 {
+		t.Fatalf("unexpected rib entries: %+v", diffs)
+	}
+}
@@ -0,0 +1,148 @@
+package bmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultStreamBufferSize defines the default number of decoded messages Stream buffers
+// before blocking the decoding goroutine.
+const DefaultStreamBufferSize = 64
+
+// Stream decodes BMP messages read from r in a goroutine and sends them to the returned messages
+// channel, buffered with DefaultStreamBufferSize. See StreamWithBufferSize for details.
+func Stream(ctx context.Context, r io.Reader) (<-chan *Message, <-chan error) {
+	return StreamWithBufferSize(ctx, r, DefaultStreamBufferSize)
+}
+
+// StreamWithBufferSize decodes BMP messages read from r in a goroutine and sends them to the returned
+// messages channel, the channel has a buffer of size messages, when the buffer is full, reading from r
+// stops until the consumer catches up. Decoding stops when ctx is cancelled, r returns io.EOF or a message
+// fails to decode, in all cases both channels get closed. Any error other than io.EOF on a message boundary
+// and context cancellation is sent to the errors channel before it gets closed.
+func StreamWithBufferSize(ctx context.Context, r io.Reader, size int) (<-chan *Message, <-chan error) {
+	if size < 0 {
+		size = 0
+	}
+	msgs := make(chan *Message, size)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(msgs)
+		for ctx.Err() == nil {
+			msg, err := readMessage(r)
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					errs <- err
+				}
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return msgs, errs
+}
+
+// readMessage reads a single BMP message from r and decodes it, io.EOF is returned only
+// when r has no more data on a message boundary.
+func readMessage(r io.Reader) (*Message, error) {
+	b := make([]byte, CommonHeaderLength)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated common header: %w", err)
+		}
+		return nil, err
+	}
+	ch, err := UnmarshalCommonHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if ch.MessageLength < CommonHeaderLength {
+		return nil, fmt.Errorf("invalid message length %d in common header", ch.MessageLength)
+	}
+	b = make([]byte, int(ch.MessageLength)-CommonHeaderLength)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("truncated message of type %d: %w", ch.MessageType, err)
+	}
+
+	return UnmarshalBMPMessage(ch, b)
+}
+
+// UnmarshalMessage decodes a single BMP message carried in b, starting with Common Header. When Message Length
+// of Common Header does not match the length of b, LengthMismatchError is returned, unless trustLength is set,
+// in which case b is truncated or padded with zeros to Message Length before decoding, it helps recovering
+// messages from captures with imperfect TCP reassembly.
+func UnmarshalMessage(b []byte, trustLength bool) (*Message, error) {
+	ch, err := UnmarshalCommonHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if ch.MessageLength < CommonHeaderLength {
+		return nil, fmt.Errorf("invalid message length %d in common header", ch.MessageLength)
+	}
+	l := int(ch.MessageLength)
+	if l != len(b) {
+		if !trustLength {
+			return nil, &LengthMismatchError{
+				MessageType:   ch.MessageType,
+				MessageLength: ch.MessageLength,
+				Available:     len(b),
+			}
+		}
+		m := make([]byte, l)
+		copy(m, b)
+		b = m
+	}
+
+	return UnmarshalBMPMessage(ch, b[CommonHeaderLength:])
+}
+
+// UnmarshalBMPMessage builds BMP Message object from the message's Common Header and the rest
+// of the message following it.
+func UnmarshalBMPMessage(ch *CommonHeader, b []byte) (*Message, error) {
+	msg := &Message{}
+	var err error
+	switch ch.MessageType {
+	case RouteMonitorMsg, StatsReportMsg, PeerDownMsg, PeerUpMsg, RouteMirrorMsg:
+		if len(b) < PerPeerHeaderLength {
+			return nil, fmt.Errorf("not enough bytes to unmarshal per peer header of message type %d", ch.MessageType)
+		}
+		if msg.PeerHeader, err = UnmarshalPerPeerHeader(b[:PerPeerHeaderLength]); err != nil {
+			return nil, err
+		}
+		b = b[PerPeerHeaderLength:]
+	}
+	switch ch.MessageType {
+	case RouteMonitorMsg:
+		msg.Payload, err = UnmarshalBMPRouteMonitorMessage(b)
+	case StatsReportMsg:
+		msg.Payload, err = UnmarshalBMPStatsReportMessage(b)
+	case PeerDownMsg:
+		msg.Payload, err = UnmarshalPeerDownMessage(b)
+	case PeerUpMsg:
+		msg.Payload, err = UnmarshalPeerUpMessage(b, msg.PeerHeader.IsRemotePeerIPv6())
+	case InitiationMsg:
+		msg.Payload, err = UnmarshalInitiationMessage(b)
+	case TerminationMsg:
+		msg.Payload, err = UnmarshalTLV(b)
+	case RouteMirrorMsg:
+		msg.Payload, err = UnmarshalRouteMirroringMessage(b)
+	default:
+		return nil, fmt.Errorf("unknown message type %d", ch.MessageType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
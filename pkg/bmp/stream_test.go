@@ -0,0 +1,165 @@
+package bmp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	// Initiation message followed by Peer Up message
+	stream := []byte{3, 0, 0, 0, 32, 4, 0, 1, 0, 10, 32, 55, 46, 50, 46, 49, 46, 50, 51, 73, 0, 2, 0, 8, 120, 114, 118, 57, 107, 45, 114, 49, 3, 0, 0, 0, 234, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 192, 168, 80, 103, 0, 0, 19, 206, 57, 112, 1, 254, 94, 98, 129, 171, 0, 0, 215, 126, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 192, 168, 80, 128, 0, 179, 131, 152, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 91, 1, 4, 19, 206, 0, 90, 192, 168, 8, 8, 62, 2, 6, 1, 4, 0, 1, 0, 1, 2, 6, 1, 4, 0, 1, 0, 4, 2, 6, 1, 4, 0, 1, 0, 128, 2, 2, 128, 0, 2, 2, 2, 0, 2, 6, 65, 4, 0, 0, 19, 206, 2, 20, 5, 18, 0, 1, 0, 1, 0, 2, 0, 1, 0, 2, 0, 2, 0, 1, 0, 128, 0, 2, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 75, 1, 4, 19, 206, 0, 90, 57, 112, 1, 254, 46, 2, 44, 2, 0, 1, 4, 0, 1, 0, 1, 1, 4, 0, 2, 0, 1, 1, 4, 0, 1, 0, 4, 1, 4, 0, 2, 0, 4, 1, 4, 0, 1, 0, 128, 1, 4, 0, 2, 0, 128, 65, 4, 0, 0, 19, 206}
+	tests := []struct {
+		name    string
+		input   []byte
+		size    int
+		expect  []byte
+		wantErr bool
+	}{
+		{
+			name:   "initiation and peer up",
+			input:  stream,
+			size:   DefaultStreamBufferSize,
+			expect: []byte{InitiationMsg, PeerUpMsg},
+		},
+		{
+			name:   "unbuffered",
+			input:  stream,
+			size:   0,
+			expect: []byte{InitiationMsg, PeerUpMsg},
+		},
+		{
+			name:    "peer up without body",
+			input:   stream[:38],
+			size:    DefaultStreamBufferSize,
+			expect:  []byte{InitiationMsg},
+			wantErr: true,
+		},
+		{
+			name:    "truncated peer up",
+			input:   stream[:100],
+			size:    DefaultStreamBufferSize,
+			expect:  []byte{InitiationMsg},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, errs := StreamWithBufferSize(context.Background(), bytes.NewReader(tt.input), tt.size)
+			got := make([]byte, 0)
+			for msg := range msgs {
+				switch msg.Payload.(type) {
+				case *InitiationMessage:
+					got = append(got, InitiationMsg)
+				case *PeerUpMessage:
+					if msg.PeerHeader == nil {
+						t.Fatal("expected per peer header for peer up message, got nil")
+					}
+					got = append(got, PeerUpMsg)
+				default:
+					t.Fatalf("unexpected payload type %T", msg.Payload)
+				}
+			}
+			err := <-errs
+			if err != nil && !tt.wantErr {
+				t.Fatalf("supposed to succeed but failed with error: %+v", err)
+			}
+			if err == nil && tt.wantErr {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !bytes.Equal(tt.expect, got) {
+				t.Fatalf("expected message types %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Endless stream of empty Termination messages
+	r := &repeatReader{b: []byte{3, 0, 0, 0, 6, 5}}
+	msgs, errs := StreamWithBufferSize(ctx, r, 0)
+	for range msgs {
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("expected no error on cancellation, got: %+v", err)
+	}
+}
+
+type repeatReader struct {
+	b []byte
+	p int
+}
+
+func (r *repeatReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = r.b[r.p%len(r.b)]
+		r.p++
+	}
+	return len(b), nil
+}
+
+func TestUnmarshalMessageLengthMismatch(t *testing.T) {
+	// Initiation message of 32 bytes
+	initiation := []byte{3, 0, 0, 0, 32, 4, 0, 1, 0, 10, 32, 55, 46, 50, 46, 49, 46, 50, 51, 73, 0, 2, 0, 8, 120, 114, 118, 57, 107, 45, 114, 49}
+	tests := []struct {
+		name        string
+		input       []byte
+		trustLength bool
+		available   int
+		wantErr     bool
+	}{
+		{
+			name:  "matching length",
+			input: initiation,
+		},
+		{
+			name:      "length overrun",
+			input:     initiation[:28],
+			available: 28,
+			wantErr:   true,
+		},
+		{
+			name:        "length overrun trusting length",
+			input:       initiation[:28],
+			trustLength: true,
+		},
+		{
+			name:      "trailing bytes",
+			input:     append(append([]byte{}, initiation...), 0, 0, 0, 0),
+			available: 36,
+			wantErr:   true,
+		},
+		{
+			name:        "trailing bytes trusting length",
+			input:       append(append([]byte{}, initiation...), 0, 0, 0, 0),
+			trustLength: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalMessage(tt.input, tt.trustLength)
+			if tt.wantErr {
+				if !errors.Is(err, ErrLengthMismatch) {
+					t.Fatalf("expected ErrLengthMismatch, got: %+v", err)
+				}
+				var lErr *LengthMismatchError
+				if !errors.As(err, &lErr) {
+					t.Fatalf("expected LengthMismatchError, got: %T", err)
+				}
+				if lErr.MessageType != InitiationMsg || lErr.MessageLength != 32 || lErr.Available != tt.available {
+					t.Fatalf("expected type %d length 32 available %d, got %+v", InitiationMsg, tt.available, *lErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("supposed to succeed but failed with error: %+v", err)
+			}
+			if _, ok := msg.Payload.(*InitiationMessage); !ok {
+				t.Fatalf("expected initiation message, got %T", msg.Payload)
+			}
+		})
+	}
+}
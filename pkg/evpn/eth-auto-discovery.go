@@ -20,6 +20,10 @@ func (t *EthAutoDiscovery) getRD() string {
 	return t.RD.String()
 }
 
+func (t *EthAutoDiscovery) getBaseRD() *base.RD {
+	return t.RD
+}
+
 func (t *EthAutoDiscovery) getESI() *ESI {
 	return t.ESI
 }
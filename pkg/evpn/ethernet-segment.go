@@ -20,6 +20,10 @@ func (t *EthernetSegment) getRD() string {
 	return t.RD.String()
 }
 
+func (t *EthernetSegment) getBaseRD() *base.RD {
+	return t.RD
+}
+
 func (t *EthernetSegment) getESI() *ESI {
 	return t.ESI
 }
@@ -1,7 +1,9 @@
 package evpn
 
 import (
+	"encoding/binary"
 	"fmt"
+	"net"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/gobmp/pkg/base"
@@ -12,6 +14,7 @@ import (
 type RouteTypeSpec interface {
 	GetRouteTypeSpec() interface{}
 	getRD() string
+	getBaseRD() *base.RD
 	getESI() *ESI
 	getTag() []byte
 	getMAC() *MACAddress
@@ -45,6 +48,36 @@ func (n *NLRI) GetEVPNRD() string {
 	return n.getRD()
 }
 
+// IsAutoRD returns true if RD of the nlri looks auto-derived, RFC 7432 Section 7.9 defines auto-derived RD
+// as RD type 1 with Router ID in the Administrator field and EVI based number in the Assigned Number field.
+// It is a heuristic, a manually configured RD of the same structure cannot be told apart.
+func (n *NLRI) IsAutoRD() bool {
+	rd := n.getBaseRD()
+	if rd == nil || rd.Type != 1 || len(rd.Value) != 6 {
+		return false
+	}
+	rid := net.IP(rd.Value[0:4])
+	if rid.IsUnspecified() || rid.IsMulticast() || rid.Equal(net.IPv4bcast) {
+		return false
+	}
+
+	return binary.BigEndian.Uint16(rd.Value[4:6]) != 0
+}
+
+// IsAutoRD returns true if all nlris of the route carry auto-derived RD, see NLRI's IsAutoRD for details.
+func (r *Route) IsAutoRD() bool {
+	if len(r.Route) == 0 {
+		return false
+	}
+	for _, n := range r.Route {
+		if !n.IsAutoRD() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // GetEVPNESI returns Ethernet Segment Identifier
 func (n *NLRI) GetEVPNESI() *ESI {
 	return n.getESI()
@@ -85,6 +118,27 @@ func (n *NLRI) GetEVPNLabel() []*base.Label {
 	return n.getLabel()
 }
 
+// GetL3VNI returns L3 VNI of symmetric IRB route and true, for MAC/IP Advertisement route it is carried
+// in the second label field, for IP Prefix route in the only label field, RFC 9135 and RFC 9136.
+// For any other route or Type 2 route without the second label, false is returned.
+func (n *NLRI) GetL3VNI() (uint32, bool) {
+	labels := n.getLabel()
+	switch n.RouteType {
+	case 2:
+		if len(labels) != 2 {
+			return 0, false
+		}
+		return labels[1].GetRawValue(), true
+	case 5:
+		if len(labels) == 0 {
+			return 0, false
+		}
+		return labels[0].GetRawValue(), true
+	}
+
+	return 0, false
+}
+
 // UnmarshalEVPNNLRI instantiates an EVPN NLRI object
 func UnmarshalEVPNNLRI(b []byte) (*Route, error) {
 	if glog.V(6) {
@@ -154,9 +208,124 @@ func MakeESI(b []byte) (*ESI, error) {
 	return &esi, nil
 }
 
+// ESI Types as defined in https://www.rfc-editor.org/rfc/rfc7432#section-5
+const (
+	// ESIArbitrary defines ESI Type 0, the ESI value is configured by the operator
+	ESIArbitrary = 0
+	// ESILACP defines ESI Type 1, the ESI value is auto-generated from IEEE 802.1AX LACP
+	ESILACP = 1
+	// ESIBridge defines ESI Type 2, the ESI value is auto-generated from Root Bridge MAC address and priority
+	ESIBridge = 2
+	// ESIMAC defines ESI Type 3, the ESI value is auto-generated from System MAC address
+	ESIMAC = 3
+	// ESIRouterID defines ESI Type 4, the ESI value is auto-generated from Router ID
+	ESIRouterID = 4
+	// ESIAS defines ESI Type 5, the ESI value is auto-generated from Autonomous System number
+	ESIAS = 5
+)
+
+// ESIInfo defines Ethernet Segment Identifier decoded into its type specific fields, only the fields
+// defined by the ESI type are set, Value carries the raw 9 bytes value of type 0 and of unknown types.
+type ESIInfo struct {
+	Type uint8
+	// MAC is CE LACP System MAC for type 1, Root Bridge MAC for type 2 and System MAC for type 3
+	MAC *MACAddress
+	// PortKey is CE LACP Port Key of type 1
+	PortKey uint16
+	// Priority is Root Bridge Priority of type 2
+	Priority uint16
+	// RouterID is Router ID of type 4
+	RouterID net.IP
+	// AS is Autonomous System number of type 5
+	AS uint32
+	// LocalDiscriminator is set for types 3, 4 and 5
+	LocalDiscriminator uint32
+	Value              []byte
+}
+
+// ParseESI decodes Ethernet Segment Identifier type specific fields
+func ParseESI(b [10]byte) *ESIInfo {
+	info := &ESIInfo{
+		Type: b[0],
+	}
+	switch info.Type {
+	case ESILACP:
+		info.MAC = &MACAddress{}
+		copy(info.MAC[:], b[1:7])
+		info.PortKey = binary.BigEndian.Uint16(b[7:9])
+	case ESIBridge:
+		info.MAC = &MACAddress{}
+		copy(info.MAC[:], b[1:7])
+		info.Priority = binary.BigEndian.Uint16(b[7:9])
+	case ESIMAC:
+		info.MAC = &MACAddress{}
+		copy(info.MAC[:], b[1:7])
+		info.LocalDiscriminator = uint32(b[7])<<16 | uint32(b[8])<<8 | uint32(b[9])
+	case ESIRouterID:
+		info.RouterID = net.IP(append([]byte{}, b[1:5]...))
+		info.LocalDiscriminator = binary.BigEndian.Uint32(b[5:9])
+	case ESIAS:
+		info.AS = binary.BigEndian.Uint32(b[1:5])
+		info.LocalDiscriminator = binary.BigEndian.Uint32(b[5:9])
+	default:
+		info.Value = append([]byte{}, b[1:]...)
+	}
+
+	return info
+}
+
+// Type returns the type of Ethernet Segment Identifier
+func (esi ESI) Type() uint8 {
+	return esi[0]
+}
+
+// String returns a human readable representation of Ethernet Segment Identifier built from its
+// decoded fields, see ESIInfo's String.
+func (esi ESI) String() string {
+	return ParseESI(esi).String()
+}
+
+// String returns a human readable representation of decoded Ethernet Segment Identifier, the format
+// depends on ESI type, for unknown types the raw value is returned.
+func (info *ESIInfo) String() string {
+	switch info.Type {
+	case ESIArbitrary:
+		return fmt.Sprintf("type 0 manual %s", hexString(info.Value))
+	case ESILACP:
+		return fmt.Sprintf("type 1 lacp system mac %s port key %d", net.HardwareAddr(info.MAC[:]).String(), info.PortKey)
+	case ESIBridge:
+		return fmt.Sprintf("type 2 root bridge mac %s priority %d", net.HardwareAddr(info.MAC[:]).String(), info.Priority)
+	case ESIMAC:
+		return fmt.Sprintf("type 3 system mac %s local discriminator %d", net.HardwareAddr(info.MAC[:]).String(), info.LocalDiscriminator)
+	case ESIRouterID:
+		return fmt.Sprintf("type 4 router id %s local discriminator %d", info.RouterID.String(), info.LocalDiscriminator)
+	case ESIAS:
+		return fmt.Sprintf("type 5 as %d local discriminator %d", info.AS, info.LocalDiscriminator)
+	}
+
+	return fmt.Sprintf("type %d %s", info.Type, hexString(info.Value))
+}
+
+func hexString(b []byte) string {
+	s := ""
+	for i, v := range b {
+		s += fmt.Sprintf("%02x", v)
+		if i < len(b)-1 {
+			s += ":"
+		}
+	}
+
+	return s
+}
+
 // MACAddress defines 6 bytes for Ethernet MAC Address field
 type MACAddress [6]byte
 
+// String returns a string representation of MAC Address in colon separated hex format
+func (mac MACAddress) String() string {
+	return net.HardwareAddr(mac[:]).String()
+}
+
 // MakeMACAddress makes an instance of Ethernet MAC Address from a slice of bytes
 func MakeMACAddress(b []byte) (*MACAddress, error) {
 	if len(b) != 6 {
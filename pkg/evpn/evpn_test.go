@@ -1,6 +1,7 @@
 package evpn
 
 import (
+	"net"
 	"reflect"
 	"testing"
 
@@ -268,3 +269,219 @@ func TestUnmarshalEVPNNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestMACIPAdvertisementESI(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+	}{
+		{
+			name:   "type 2 route with lacp esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x01, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 1 lacp system mac 00:81:c4:bc:77:8a port key 16",
+		},
+		{
+			name:   "type 2 route with mac based esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x03, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 3 system mac 00:81:c4:bc:77:8a local discriminator 256",
+		},
+		{
+			name:   "type 2 route with manual esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 0 manual 11:11:11:11:11:11:11:11:11",
+		},
+		{
+			name:   "type 2 route with root bridge esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x02, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 2 root bridge mac 00:81:c4:bc:77:8a priority 32768",
+		},
+		{
+			name:   "type 2 route with router id esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x04, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 4 router id 10.0.0.1 local discriminator 100",
+		},
+		{
+			name:   "type 2 route with as esi",
+			input:  []byte{0x02, 0x21, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x05, 0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			expect: "type 5 as 65000 local discriminator 7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := UnmarshalEVPNNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("test failed with error: %+v", err)
+			}
+			if len(r.Route) != 1 {
+				t.Fatalf("expected 1 route, got %d", len(r.Route))
+			}
+			esi := r.Route[0].GetEVPNESI()
+			if esi == nil {
+				t.Fatal("expected esi, got nil")
+			}
+			if got := esi.String(); got != tt.expect {
+				t.Fatalf("expected esi %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseESI(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  [10]byte
+		expect *ESIInfo
+	}{
+		{
+			name:  "type 1 lacp",
+			input: [10]byte{0x01, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x10, 0x00},
+			expect: &ESIInfo{
+				Type:    ESILACP,
+				MAC:     &MACAddress{0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a},
+				PortKey: 16,
+			},
+		},
+		{
+			name:  "type 3 system mac",
+			input: [10]byte{0x03, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x01, 0x00},
+			expect: &ESIInfo{
+				Type:               ESIMAC,
+				MAC:                &MACAddress{0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a},
+				LocalDiscriminator: 256,
+			},
+		},
+		{
+			name:  "type 4 router id",
+			input: [10]byte{0x04, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x64, 0x00},
+			expect: &ESIInfo{
+				Type:               ESIRouterID,
+				RouterID:           net.IP{10, 0, 0, 1},
+				LocalDiscriminator: 100,
+			},
+		},
+		{
+			name:  "type 5 as number",
+			input: [10]byte{0x05, 0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x07, 0x00},
+			expect: &ESIInfo{
+				Type:               ESIAS,
+				AS:                 65000,
+				LocalDiscriminator: 7,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseESI(tt.input); !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected esi %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalEVPNMACIPAdvertisement(t *testing.T) {
+	mac, _ := MakeMACAddress([]byte{0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a})
+	tests := []struct {
+		name  string
+		input []byte
+		mac   *MACAddress
+		ip    []byte
+		fail  bool
+	}{
+		{
+			name:  "mac only",
+			input: []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x00, 0x18, 0xa9, 0x71},
+			mac:   mac,
+		},
+		{
+			name:  "mac and ipv4",
+			input: []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x20, 0x0a, 0x0a, 0x0a, 0x01, 0x18, 0xa9, 0x71},
+			mac:   mac,
+			ip:    []byte{0x0a, 0x0a, 0x0a, 0x01},
+		},
+		{
+			name: "mac and ipv6",
+			input: []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a,
+				0x80, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x18, 0xa9, 0x71},
+			mac: mac,
+			ip:  []byte{0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			name:  "invalid ip address length",
+			input: []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x18, 0x0a, 0x0a, 0x0a, 0x18, 0xa9, 0x71},
+			fail:  true,
+		},
+		{
+			name:  "truncated ipv6 address",
+			input: []byte{0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x30, 0x00, 0x81, 0xc4, 0xbc, 0x77, 0x8a, 0x80, 0x20, 0x01, 0x0d, 0xb8},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalEVPNMACIPAdvertisement(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("expected to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("expected to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.mac, got.MACAddr) {
+				t.Fatalf("expected mac %+v, got %+v", tt.mac, got.MACAddr)
+			}
+			if !reflect.DeepEqual(tt.ip, got.IPAddr) {
+				t.Fatalf("expected ip %+v, got %+v", tt.ip, got.IPAddr)
+			}
+			if len(got.Label) != 1 || got.Label[0].Value != 101015 {
+				t.Fatalf("expected a single label 101015, got %+v", got.Label)
+			}
+		})
+	}
+}
+
+func TestIsAutoRD(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect bool
+	}{
+		{
+			name: "auto derived type 1 rd",
+			// Type 3 route with RD 172.31.101.6:100
+			input:  []byte{0x03, 0x11, 0x00, 0x01, 0xac, 0x1f, 0x65, 0x06, 0x00, 0x64, 0x00, 0x00, 0x00, 0x00, 0x20, 0xac, 0x1f, 0x65, 0x06},
+			expect: true,
+		},
+		{
+			name: "configured type 0 rd",
+			// Type 3 route with RD 200:50
+			input:  []byte{0x03, 0x11, 0x00, 0x00, 0x00, 0xc8, 0x00, 0x00, 0x00, 0x32, 0x00, 0x00, 0x00, 0x00, 0x20, 0xac, 0x1f, 0x65, 0x06},
+			expect: false,
+		},
+		{
+			name: "type 1 rd with zero assigned number",
+			// Type 3 route with RD 172.31.101.6:0
+			input:  []byte{0x03, 0x11, 0x00, 0x01, 0xac, 0x1f, 0x65, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20, 0xac, 0x1f, 0x65, 0x06},
+			expect: false,
+		},
+		{
+			name: "type 1 rd with unspecified router id",
+			// Type 3 route with RD 0.0.0.0:100
+			input:  []byte{0x03, 0x11, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x00, 0x20, 0xac, 0x1f, 0x65, 0x06},
+			expect: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := UnmarshalEVPNNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal evpn nlri with error: %+v", err)
+			}
+			if got := route.IsAutoRD(); got != tt.expect {
+				t.Fatalf("expected auto rd %t, got %t", tt.expect, got)
+			}
+		})
+	}
+}
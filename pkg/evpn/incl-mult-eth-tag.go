@@ -19,6 +19,10 @@ func (t *InclusiveMulticastEthTag) getRD() string {
 	return t.RD.String()
 }
 
+func (t *InclusiveMulticastEthTag) getBaseRD() *base.RD {
+	return t.RD
+}
+
 func (t *InclusiveMulticastEthTag) getESI() *ESI {
 	return nil
 }
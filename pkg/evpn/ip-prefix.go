@@ -27,6 +27,10 @@ func (t *IPPrefix) getRD() string {
 	return t.RD.String()
 }
 
+func (t *IPPrefix) getBaseRD() *base.RD {
+	return t.RD
+}
+
 func (t *IPPrefix) getESI() *ESI {
 	return t.ESI
 }
@@ -1,6 +1,10 @@
 package evpn
 
-import "github.com/sbezverk/gobmp/pkg/base"
+import (
+	"fmt"
+
+	"github.com/sbezverk/gobmp/pkg/base"
+)
 
 // MACIPAdvertisement defines a structure of Route type 2
 // (MAC IP Advertisement route)
@@ -24,6 +28,10 @@ func (t *MACIPAdvertisement) getRD() string {
 	return t.RD.String()
 }
 
+func (t *MACIPAdvertisement) getBaseRD() *base.RD {
+	return t.RD
+}
+
 func (t *MACIPAdvertisement) getESI() *ESI {
 	return t.ESI
 }
@@ -56,11 +64,16 @@ func (t *MACIPAdvertisement) getLabel() []*base.Label {
 	return t.Label
 }
 
-// UnmarshalEVPNMACIPAdvertisement instantiates new instance of a Ethernet Auto Discovery route type object
+// UnmarshalEVPNMACIPAdvertisement instantiates new instance of a MAC IP Advertisement route type object,
+// IP Address Length field of 0, 32 or 128 bits defines whether the route carries no IP, IPv4 or IPv6 address.
 func UnmarshalEVPNMACIPAdvertisement(b []byte) (*MACIPAdvertisement, error) {
 	var err error
 	t := MACIPAdvertisement{}
 	p := 0
+	// RD, ESI, Ethernet Tag and MAC Address Length
+	if len(b) < 23 {
+		return nil, fmt.Errorf("not enough bytes to unmarshal mac ip advertisement route")
+	}
 	t.RD, err = base.MakeRD(b[p : p+8])
 	if err != nil {
 		return nil, err
@@ -78,21 +91,37 @@ func UnmarshalEVPNMACIPAdvertisement(b []byte) (*MACIPAdvertisement, error) {
 	p++
 	l := int(t.MACAddrLength / 8)
 	if l != 0 {
+		if p+l > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal mac address of length %d", t.MACAddrLength)
+		}
 		t.MACAddr, err = MakeMACAddress(b[p : p+l])
 		if err != nil {
 			return nil, err
 		}
 		p += l
 	}
+	if p >= len(b) {
+		return nil, fmt.Errorf("not enough bytes to unmarshal ip address length")
+	}
 	t.IPAddrLength = b[p]
 	p++
-	l = int(t.IPAddrLength / 8)
-	if t.IPAddrLength != 0 {
+	switch t.IPAddrLength {
+	case 0:
+	case 32, 128:
+		l = int(t.IPAddrLength / 8)
+		if p+l > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal ip address of length %d", t.IPAddrLength)
+		}
 		t.IPAddr = make([]byte, l)
 		copy(t.IPAddr, b[p:p+l])
 		p += l
+	default:
+		return nil, fmt.Errorf("invalid ip address length %d", t.IPAddrLength)
 	}
 	for i := 0; p < len(b); i++ {
+		if p+3 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal mpls label")
+		}
 		l, err := base.MakeLabel(b[p : p+3])
 		if err != nil {
 			return nil, err
@@ -6,8 +6,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
+	"strings"
 
 	"github.com/golang/glog"
+	"github.com/sbezverk/gobmp/pkg/base"
 	"github.com/sbezverk/tools"
 )
 
@@ -19,7 +23,12 @@ type Spec interface {
 
 // NLRI defines Flowspec NLRI structure
 type NLRI struct {
-	Length   uint16
+	// AFI and SAFI of the address family the NLRI was decoded for, AFI 1 or 2 with SAFI 133 or 134
+	AFI    uint16
+	SAFI   uint8
+	Length uint16
+	// RD is Route Distinguisher of VPN Flowspec NLRI (SAFI 134), nil for Flowspec NLRI (SAFI 133)
+	RD       *base.RD
 	Spec     []Spec
 	SpecHash string
 }
@@ -29,6 +38,91 @@ func (fs *NLRI) GetSpecHash() string {
 	return fs.SpecHash
 }
 
+// CanonicalKey returns MD5 hash of the rule with its components ordered by component type, rules
+// matching the same traffic produce the same key regardless of the order components were listed in,
+// so the key can be used to dedup rules learned from different sources. Address family and
+// Route Distinguisher are part of the key.
+func (fs *NLRI) CanonicalKey() string {
+	specs := make([]Spec, len(fs.Spec))
+	copy(specs, fs.Spec)
+	sort.SliceStable(specs, func(i, j int) bool {
+		return getSpecType(specs[i]) < getSpecType(specs[j])
+	})
+	key := fmt.Sprintf("%d/%d", fs.AFI, fs.SAFI)
+	if fs.RD != nil {
+		key += "/" + fs.RD.String()
+	}
+	b := []byte(key)
+	for _, spec := range specs {
+		sp, err := spec.MarshalJSON()
+		if err != nil {
+			// Should never happen, falling back to the type of the component
+			sp = []byte{getSpecType(spec)}
+		}
+		b = append(b, sp...)
+	}
+	s := md5.Sum(b)
+
+	return hex.EncodeToString(s[:])
+}
+
+// String returns a human readable match expression of the rule, components are rendered in the order
+// they were received and separated by ", ", for example "dst 10.0.0.0/24, proto TCP, dst-port 80 || 443",
+// VPN Flowspec rules are prefixed with the Route Distinguisher.
+func (fs *NLRI) String() string {
+	components := make([]string, 0, len(fs.Spec)+1)
+	if fs.RD != nil {
+		components = append(components, "rd "+fs.RD.String())
+	}
+	for _, spec := range fs.Spec {
+		var c string
+		switch s := spec.(type) {
+		case *PrefixSpec:
+			c = s.prefix(fs.AFI)
+		case *GenericSpec:
+			c = s.String()
+		case *TCPFlagsSpec:
+			c = s.String()
+		case *FragmentSpec:
+			c = s.String()
+		default:
+			continue
+		}
+		components = append(components, SpecType(getSpecType(spec)).String()+" "+c)
+	}
+
+	return strings.Join(components, ", ")
+}
+
+// GetGenericSpec returns the first component of type t using numeric operator and true, if the rule
+// does not carry such component, false is returned. Port (Type4) matches either source or destination
+// port and is returned only for Type4, Destination Port (Type5) and Source Port (Type6) are separate
+// components.
+func (fs *NLRI) GetGenericSpec(t SpecType) (*GenericSpec, bool) {
+	for _, spec := range fs.Spec {
+		if s, ok := spec.(*GenericSpec); ok && SpecType(s.SpecType) == t {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
+
+func getSpecType(spec Spec) uint8 {
+	switch s := spec.(type) {
+	case *PrefixSpec:
+		return s.SpecType
+	case *GenericSpec:
+		return s.SpecType
+	case *TCPFlagsSpec:
+		return s.SpecType
+	case *FragmentSpec:
+		return s.SpecType
+	}
+
+	return 0
+}
+
 // SpecType defines Flowspec Spec type
 type SpecType uint8
 
@@ -57,21 +151,85 @@ const (
 	Type11 SpecType = 11
 	// Type12 defines Flowspec Specification type for Fragment
 	Type12 SpecType = 12
+	// Type13 defines Flowspec Specification type for IPv6 Flow Label, RFC 8956
+	Type13 SpecType = 13
 )
 
+var specTypeNames = map[SpecType]string{
+	Type1:  "dst",
+	Type2:  "src",
+	Type3:  "proto",
+	Type4:  "port",
+	Type5:  "dst-port",
+	Type6:  "src-port",
+	Type7:  "icmp-type",
+	Type8:  "icmp-code",
+	Type9:  "tcp-flags",
+	Type10: "pkt-len",
+	Type11: "dscp",
+	Type12: "fragment",
+	Type13: "flow-label",
+}
+
+// String returns the short name of Flowspec Spec type, for unknown types the numeric value is returned
+func (t SpecType) String() string {
+	if n, ok := specTypeNames[t]; ok {
+		return n
+	}
+
+	return fmt.Sprintf("type-%d", uint8(t))
+}
+
 // UnmarshalFlowspecNLRI creates an instance of Flowspec NLRI from a slice of bytes
 func UnmarshalFlowspecNLRI(b []byte) (*NLRI, error) {
 	if glog.V(5) {
 		glog.Infof("Flowspec NLRI Raw: %s", tools.MessageHex(b))
 	}
+	return unmarshalFlowspecNLRI(b, 1, 133)
+}
+
+// UnmarshalVPNFlowspecNLRI creates an instance of VPN Flowspec NLRI (SAFI 134) from a slice of bytes,
+// the NLRI's length covers 8 bytes Route Distinguisher which precedes Flowspec components, RFC 8955 section 8.
+func UnmarshalVPNFlowspecNLRI(b []byte) (*NLRI, error) {
+	if glog.V(5) {
+		glog.Infof("VPN Flowspec NLRI Raw: %s", tools.MessageHex(b))
+	}
+	return unmarshalFlowspecNLRI(b, 1, 134)
+}
+
+// UnmarshalIPv6FlowspecNLRI creates an instance of IPv6 Flowspec NLRI from a slice of bytes,
+// IPv6 Prefix components carry Prefix Offset, RFC 8956 section 3.1.
+func UnmarshalIPv6FlowspecNLRI(b []byte) (*NLRI, error) {
+	if glog.V(5) {
+		glog.Infof("IPv6 Flowspec NLRI Raw: %s", tools.MessageHex(b))
+	}
+	return unmarshalFlowspecNLRI(b, 2, 133)
+}
+
+// UnmarshalIPv6VPNFlowspecNLRI creates an instance of IPv6 VPN Flowspec NLRI (AFI 2 SAFI 134) from a slice of bytes
+func UnmarshalIPv6VPNFlowspecNLRI(b []byte) (*NLRI, error) {
+	if glog.V(5) {
+		glog.Infof("IPv6 VPN Flowspec NLRI Raw: %s", tools.MessageHex(b))
+	}
+	return unmarshalFlowspecNLRI(b, 2, 134)
+}
+
+func unmarshalFlowspecNLRI(b []byte, afi uint16, safi uint8) (*NLRI, error) {
 	if len(b) == 0 {
 		return nil, fmt.Errorf("NLRI length is 0")
 	}
-	fs := &NLRI{}
+	fs := &NLRI{
+		AFI:  afi,
+		SAFI: safi,
+	}
+	vpn := safi == 134
 	p := 0
 	if b[p]&0xf0 == 0xf0 {
-		// NLRI length is encoded into 2 bytes
-		binary.BigEndian.PutUint16(b[p:p+2], fs.Length)
+		// NLRI length of 240 bytes or more is encoded into 2 bytes as 0xfnnn, RFC 8955
+		if len(b) < 2 {
+			return nil, fmt.Errorf("not enough bytes to decode 2 bytes NLRI length")
+		}
+		fs.Length = binary.BigEndian.Uint16(b[p:p+2]) & 0x0fff
 		p += 2
 	} else {
 		// Otherwise it is encoded in the single byte
@@ -81,6 +239,17 @@ func UnmarshalFlowspecNLRI(b []byte) (*NLRI, error) {
 	if p+int(fs.Length) != len(b) {
 		return nil, fmt.Errorf("invalid length encoded length %d does not match with slice length %d", fs.Length, len(b))
 	}
+	if vpn {
+		if p+8 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to decode vpn flowspec route distinguisher")
+		}
+		rd, err := base.MakeRD(b[p : p+8])
+		if err != nil {
+			return nil, err
+		}
+		fs.RD = rd
+		p += 8
+	}
 	for p < len(b) {
 		t := b[p]
 		l := 0
@@ -90,7 +259,11 @@ func UnmarshalFlowspecNLRI(b []byte) (*NLRI, error) {
 		case Type1:
 			fallthrough
 		case Type2:
-			spec, l, err = makePrefixSpec(b[p:])
+			if afi == 2 {
+				spec, l, err = makeIPv6PrefixSpec(b[p:])
+			} else {
+				spec, l, err = makePrefixSpec(b[p:])
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -109,14 +282,22 @@ func UnmarshalFlowspecNLRI(b []byte) (*NLRI, error) {
 		case Type10:
 			fallthrough
 		case Type11:
+			fallthrough
+		case Type13:
 			spec, l, err = makeGenericSpec(b[p:])
 			if err != nil {
 				return nil, err
 			}
-		case Type9:
-			fallthrough
 		case Type12:
-			return nil, fmt.Errorf("not implemented Flowspec type: %+v", t)
+			spec, l, err = makeFragmentSpec(b[p:])
+			if err != nil {
+				return nil, err
+			}
+		case Type9:
+			spec, l, err = makeTCPFlagsSpec(b[p:])
+			if err != nil {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("unknown Flowspec type: %+v", t)
 		}
@@ -129,6 +310,10 @@ func UnmarshalFlowspecNLRI(b []byte) (*NLRI, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The same rule installed in different VRFs must not share the hash
+	if fs.RD != nil {
+		sp = append([]byte(fs.RD.String()), sp...)
+	}
 	s := md5.Sum(sp)
 	fs.SpecHash = hex.EncodeToString(s[:])
 
@@ -189,6 +374,29 @@ func (o *Operator) MarshalJSON() ([]byte, error) {
 
 }
 
+// String returns the comparison defined by lt, gt and eq bits of numeric operator,
+// https://www.rfc-editor.org/rfc/rfc8955#section-4.2.1.1
+func (o *Operator) String() string {
+	switch {
+	case o.LTBit && o.GTBit && o.EQBit:
+		return "true"
+	case o.LTBit && o.GTBit:
+		return "!="
+	case o.LTBit && o.EQBit:
+		return "<="
+	case o.GTBit && o.EQBit:
+		return ">="
+	case o.LTBit:
+		return "<"
+	case o.GTBit:
+		return ">"
+	case o.EQBit:
+		return "=="
+	}
+
+	return "false"
+}
+
 // UnmarshalJSON creates a new instance of Flowspec Operator
 func (o *Operator) UnmarshalJSON(b []byte) error {
 	t := &Operator{}
@@ -202,22 +410,34 @@ func (o *Operator) UnmarshalJSON(b []byte) error {
 
 // PrefixSpec defines a structure of Flowspec Type 1 and Type 2 (Destination/Source Prefix) spec.
 type PrefixSpec struct {
-	SpecType     uint8  `json:"type"`
-	PrefixLength uint8  `json:"prefix_len"`
+	SpecType     uint8 `json:"type"`
+	PrefixLength uint8 `json:"prefix_len"`
+	// PrefixOffset is the number of leading bits of IPv6 prefix skipped by the pattern, RFC 8956,
+	// it is always 0 for IPv4 prefix.
+	PrefixOffset uint8  `json:"prefix_offset,omitempty"`
 	Prefix       []byte `json:"prefix"`
 }
 
 func makePrefixSpec(b []byte) (Spec, int, error) {
+	if len(b) < 2 {
+		return nil, 0, fmt.Errorf("not enough bytes to decode flowspec prefix spec")
+	}
 	s := &PrefixSpec{}
 	p := 0
 	s.SpecType = b[p]
 	p++
 	s.PrefixLength = b[p]
+	if s.PrefixLength > 32 {
+		return nil, 0, fmt.Errorf("invalid flowspec ipv4 prefix length %d", s.PrefixLength)
+	}
 	l := int(s.PrefixLength / 8)
 	if b[p]%8 != 0 {
 		l++
 	}
 	p++
+	if p+l > len(b) {
+		return nil, 0, fmt.Errorf("not enough bytes to decode flowspec prefix of length %d", s.PrefixLength)
+	}
 	s.Prefix = make([]byte, l)
 	copy(s.Prefix, b[p:p+l])
 	p += int(l)
@@ -225,6 +445,37 @@ func makePrefixSpec(b []byte) (Spec, int, error) {
 	return s, p, nil
 }
 
+func makeIPv6PrefixSpec(b []byte) (Spec, int, error) {
+	if len(b) < 3 {
+		return nil, 0, fmt.Errorf("not enough bytes to decode flowspec ipv6 prefix spec")
+	}
+	s := &PrefixSpec{}
+	p := 0
+	s.SpecType = b[p]
+	p++
+	s.PrefixLength = b[p]
+	p++
+	s.PrefixOffset = b[p]
+	p++
+	if s.PrefixLength > 128 || s.PrefixOffset > s.PrefixLength {
+		return nil, 0, fmt.Errorf("invalid flowspec ipv6 prefix length %d offset %d", s.PrefixLength, s.PrefixOffset)
+	}
+	// Pattern carries only bits between the offset and the prefix length
+	bits := int(s.PrefixLength - s.PrefixOffset)
+	l := bits / 8
+	if bits%8 != 0 {
+		l++
+	}
+	if p+l > len(b) {
+		return nil, 0, fmt.Errorf("not enough bytes to decode flowspec ipv6 prefix of length %d", s.PrefixLength)
+	}
+	s.Prefix = make([]byte, l)
+	copy(s.Prefix, b[p:p+l])
+	p += l
+
+	return s, p, nil
+}
+
 // UnmarshalJSON unmarshals a slice of bytes into a new FlowSPec PrefixSpec
 func (t *PrefixSpec) UnmarshalJSON(b []byte) error {
 	s := &PrefixSpec{}
@@ -241,14 +492,42 @@ func (t *PrefixSpec) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		SpecType     uint8  `json:"type"`
 		PrefixLength uint8  `json:"prefix_len"`
+		PrefixOffset uint8  `json:"prefix_offset,omitempty"`
 		Prefix       []byte `json:"prefix"`
 	}{
 		SpecType:     t.SpecType,
 		PrefixLength: t.PrefixLength,
+		PrefixOffset: t.PrefixOffset,
 		Prefix:       t.Prefix,
 	})
 }
 
+// prefix returns the prefix of the spec in address/length notation, for IPv6 prefix with non zero
+// offset the pattern is placed at the offset bit and the offset is appended.
+func (t *PrefixSpec) prefix(afi uint16) string {
+	al := 4
+	if afi == 2 {
+		al = 16
+	}
+	addr := make([]byte, al)
+	// Pattern bits are copied starting from the offset bit, for IPv4 the offset is always 0
+	for i := 0; i < int(t.PrefixLength-t.PrefixOffset) && i/8 < len(t.Prefix); i++ {
+		if t.Prefix[i/8]&(0x80>>(i%8)) == 0 {
+			continue
+		}
+		n := int(t.PrefixOffset) + i
+		if n/8 < al {
+			addr[n/8] |= 0x80 >> (n % 8)
+		}
+	}
+	s := fmt.Sprintf("%s/%d", net.IP(addr).String(), t.PrefixLength)
+	if t.PrefixOffset != 0 {
+		s += fmt.Sprintf(" offset %d", t.PrefixOffset)
+	}
+
+	return s
+}
+
 // OpVal defines structure of Operator and Value pair
 type OpVal struct {
 	Op  *Operator `json:"operator,omitempty"`
@@ -277,6 +556,28 @@ func (o *OpVal) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// GetValue returns the value of Operator/Value pair as a number, used by numeric operators
+// of types like Port or Packet Length.
+func (o *OpVal) GetValue() uint64 {
+	var v uint64
+	for _, b := range o.Val {
+		v = v<<8 | uint64(b)
+	}
+
+	return v
+}
+
+// String returns a readable form of numeric Operator/Value pair, pairs combined with the previous
+// one by logical AND are prefixed with "&&", otherwise they are combined with logical OR.
+func (o *OpVal) String() string {
+	s := fmt.Sprintf("%s%d", o.Op.String(), o.GetValue())
+	if o.Op.ANDBit {
+		s = "&&" + s
+	}
+
+	return s
+}
+
 // UnmarshalOpVal creates a slice of Operator/Value pairs
 func UnmarshalOpVal(b []byte) ([]*OpVal, error) {
 	opvals := make([]*OpVal, 0)
@@ -308,7 +609,7 @@ func UnmarshalOpVal(b []byte) ([]*OpVal, error) {
 	return opvals, nil
 }
 
-// GenericSpec defines a structure of Flowspec Types (3,4,5,6,7,8,10,11) specs.
+// GenericSpec defines a structure of Flowspec Types (3,4,5,6,7,8,10,11,13) specs using numeric operator.
 type GenericSpec struct {
 	SpecType uint8    `json:"type,omitempty"`
 	OpVal    []*OpVal `json:"op_val_pairs,omitempty"`
@@ -359,3 +660,346 @@ func (t *GenericSpec) MarshalJSON() ([]byte, error) {
 		OpVal:    t.OpVal,
 	})
 }
+
+// ipProtocolNames maps IP protocol numbers, carried by IPv4 IP Protocol and IPv6 Next Header components,
+// to their names, https://www.iana.org/assignments/protocol-numbers
+var ipProtocolNames = map[uint64]string{
+	1:   "ICMP",
+	2:   "IGMP",
+	4:   "IPIP",
+	6:   "TCP",
+	17:  "UDP",
+	41:  "IPv6",
+	46:  "RSVP",
+	47:  "GRE",
+	50:  "ESP",
+	51:  "AH",
+	58:  "ICMPv6",
+	89:  "OSPF",
+	103: "PIM",
+	112: "VRRP",
+	115: "L2TP",
+	132: "SCTP",
+}
+
+// String returns a human readable representation of numeric Operator/Value pairs of the spec,
+// pairs are combined by "&&" or "||", equality operator is omitted, for example ">=1024 && <=2048 || 80".
+// Known protocols of IP Protocol component compared by equality or inequality are rendered by name,
+// for example "TCP || UDP".
+func (t *GenericSpec) String() string {
+	s := ""
+	for i, ov := range t.OpVal {
+		if i != 0 {
+			if ov.Op.ANDBit {
+				s += " && "
+			} else {
+				s += " || "
+			}
+		}
+		op := ov.Op.String()
+		if op != "==" {
+			s += op
+		}
+		if n, ok := ipProtocolNames[ov.GetValue()]; ok && SpecType(t.SpecType) == Type3 && (op == "==" || op == "!=") {
+			s += n
+			continue
+		}
+		s += fmt.Sprintf("%d", ov.GetValue())
+	}
+
+	return s
+}
+
+// TCPFlagsMatch defines a structure of Bitmask Operator and TCP flags bitmask value pair
+// https://www.rfc-editor.org/rfc/rfc8955#section-4.2.2.9
+type TCPFlagsMatch struct {
+	EOLBit   bool   `json:"end_of_list_bit,omitempty"`
+	ANDBit   bool   `json:"and_bit,omitempty"`
+	NotBit   bool   `json:"not,omitempty"`
+	MatchBit bool   `json:"match,omitempty"`
+	Flags    uint16 `json:"flags"`
+}
+
+// TCPFlags defines TCP header flags carried in the low order byte of TCP flags bitmask value
+type TCPFlags struct {
+	CWR bool `json:"cwr,omitempty"`
+	ECE bool `json:"ece,omitempty"`
+	URG bool `json:"urg,omitempty"`
+	ACK bool `json:"ack,omitempty"`
+	PSH bool `json:"psh,omitempty"`
+	RST bool `json:"rst,omitempty"`
+	SYN bool `json:"syn,omitempty"`
+	FIN bool `json:"fin,omitempty"`
+}
+
+// GetTCPFlags returns TCP flags of the bitmask value, when MatchBit is set, all flags must be set in
+// the packet, otherwise any of them, NotBit negates the result.
+func (m *TCPFlagsMatch) GetTCPFlags() TCPFlags {
+	return TCPFlags{
+		CWR: m.Flags&0x80 == 0x80,
+		ECE: m.Flags&0x40 == 0x40,
+		URG: m.Flags&0x20 == 0x20,
+		ACK: m.Flags&0x10 == 0x10,
+		PSH: m.Flags&0x08 == 0x08,
+		RST: m.Flags&0x04 == 0x04,
+		SYN: m.Flags&0x02 == 0x02,
+		FIN: m.Flags&0x01 == 0x01,
+	}
+}
+
+var tcpFlagNames = []struct {
+	bit  uint16
+	name string
+}{
+	{0x80, "CWR"}, {0x40, "ECE"}, {0x20, "URG"}, {0x10, "ACK"}, {0x08, "PSH"}, {0x04, "RST"}, {0x02, "SYN"}, {0x01, "FIN"},
+}
+
+// String returns a human readable representation of the match, "=" prefix means all flags must be set,
+// no prefix means any of flags, "!" negates the match, "&&" prefix is added when ANDBit is set.
+func (m *TCPFlagsMatch) String() string {
+	s := ""
+	if m.ANDBit {
+		s += "&&"
+	}
+
+	return s + m.match()
+}
+
+// match returns the representation of the match without the logical operator
+func (m *TCPFlagsMatch) match() string {
+	s := ""
+	if m.NotBit {
+		s += "!"
+	}
+	if m.MatchBit {
+		s += "="
+	}
+	names := make([]string, 0)
+	for _, f := range tcpFlagNames {
+		if m.Flags&f.bit == f.bit {
+			names = append(names, f.name)
+		}
+	}
+
+	return s + strings.Join(names, "|")
+}
+
+// TCPFlagsSpec defines a structure of Flowspec Type 9 (TCP flags) spec.
+type TCPFlagsSpec struct {
+	SpecType uint8            `json:"type,omitempty"`
+	Match    []*TCPFlagsMatch `json:"tcp_flags_match,omitempty"`
+}
+
+// String returns a human readable representation of the spec's matches combined by "&&" or "||",
+// for example "=SYN && !ACK".
+func (t *TCPFlagsSpec) String() string {
+	s := ""
+	for i, m := range t.Match {
+		if i != 0 {
+			if m.ANDBit {
+				s += " && "
+			} else {
+				s += " || "
+			}
+		}
+		s += m.match()
+	}
+
+	return s
+}
+
+func makeTCPFlagsSpec(b []byte) (Spec, int, error) {
+	s := &TCPFlagsSpec{
+		Match: make([]*TCPFlagsMatch, 0),
+	}
+	p := 0
+	s.SpecType = b[p]
+	p++
+	for eol := false; !eol; {
+		if p >= len(b) {
+			return nil, 0, fmt.Errorf("not enough bytes to unmarshal TCP flags spec")
+		}
+		op := b[p]
+		p++
+		l := 1 << ((op & 0x30) >> 4)
+		if l > 2 {
+			return nil, 0, fmt.Errorf("invalid TCP flags value length %d", l)
+		}
+		if p+l > len(b) {
+			return nil, 0, fmt.Errorf("not enough bytes to unmarshal Operator/Value pair")
+		}
+		m := &TCPFlagsMatch{
+			EOLBit:   op&0x80 == 0x80,
+			ANDBit:   op&0x40 == 0x40,
+			NotBit:   op&0x02 == 0x02,
+			MatchBit: op&0x01 == 0x01,
+		}
+		for _, v := range b[p : p+l] {
+			m.Flags = m.Flags<<8 | uint16(v)
+		}
+		p += l
+		s.Match = append(s.Match, m)
+		eol = m.EOLBit
+	}
+
+	return s, p, nil
+}
+
+// UnmarshalJSON unmarshals a slice of bytes into a new FlowSPec TCPFlagsSpec
+func (t *TCPFlagsSpec) UnmarshalJSON(b []byte) error {
+	// tcpFlagsSpec does not inherit TCPFlagsSpec's methods which prevents UnmarshalJSON recursion
+	type tcpFlagsSpec TCPFlagsSpec
+	s := &tcpFlagsSpec{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return err
+	}
+	*t = TCPFlagsSpec(*s)
+
+	return nil
+}
+
+// MarshalJSON returns a binary representation of FlowSPec TCPFlagsSpec
+func (t *TCPFlagsSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SpecType uint8            `json:"type,omitempty"`
+		Match    []*TCPFlagsMatch `json:"tcp_flags_match,omitempty"`
+	}{
+		SpecType: t.SpecType,
+		Match:    t.Match,
+	})
+}
+
+// FragmentMatch defines a structure of Bitmask Operator and Fragment bitmask value pair
+// https://www.rfc-editor.org/rfc/rfc8955#section-4.2.2.12
+type FragmentMatch struct {
+	EOLBit   bool `json:"end_of_list_bit,omitempty"`
+	ANDBit   bool `json:"and_bit,omitempty"`
+	NotBit   bool `json:"not,omitempty"`
+	MatchBit bool `json:"match,omitempty"`
+	// DF Don't Fragment
+	DF bool `json:"dont_fragment,omitempty"`
+	// IsF Is a Fragment other than the first
+	IsF bool `json:"is_fragment,omitempty"`
+	// FF First Fragment
+	FF bool `json:"first_fragment,omitempty"`
+	// LF Last Fragment
+	LF bool `json:"last_fragment,omitempty"`
+}
+
+// String returns a human readable representation of the match using the same notation as TCPFlagsMatch,
+// fragment flags are named DF, IsF, FF and LF.
+func (m *FragmentMatch) String() string {
+	s := ""
+	if m.ANDBit {
+		s += "&&"
+	}
+
+	return s + m.match()
+}
+
+// match returns the representation of the match without the logical operator
+func (m *FragmentMatch) match() string {
+	s := ""
+	if m.NotBit {
+		s += "!"
+	}
+	if m.MatchBit {
+		s += "="
+	}
+	names := make([]string, 0)
+	for _, f := range []struct {
+		set  bool
+		name string
+	}{{m.DF, "DF"}, {m.IsF, "IsF"}, {m.FF, "FF"}, {m.LF, "LF"}} {
+		if f.set {
+			names = append(names, f.name)
+		}
+	}
+
+	return s + strings.Join(names, "|")
+}
+
+// FragmentSpec defines a structure of Flowspec Type 12 (Fragment) spec.
+type FragmentSpec struct {
+	SpecType uint8            `json:"type,omitempty"`
+	Match    []*FragmentMatch `json:"fragment_match,omitempty"`
+}
+
+// String returns a human readable representation of the spec's matches combined by "&&" or "||",
+// for example "=IsF".
+func (t *FragmentSpec) String() string {
+	s := ""
+	for i, m := range t.Match {
+		if i != 0 {
+			if m.ANDBit {
+				s += " && "
+			} else {
+				s += " || "
+			}
+		}
+		s += m.match()
+	}
+
+	return s
+}
+
+func makeFragmentSpec(b []byte) (Spec, int, error) {
+	s := &FragmentSpec{
+		Match: make([]*FragmentMatch, 0),
+	}
+	p := 0
+	s.SpecType = b[p]
+	p++
+	for eol := false; !eol; {
+		if p >= len(b) {
+			return nil, 0, fmt.Errorf("not enough bytes to unmarshal Fragment spec")
+		}
+		op := b[p]
+		p++
+		l := 1 << ((op & 0x30) >> 4)
+		if p+l > len(b) {
+			return nil, 0, fmt.Errorf("not enough bytes to unmarshal Operator/Value pair")
+		}
+		// Fragment bitmask is carried in the least significant byte of the value
+		v := b[p+l-1]
+		p += l
+		m := &FragmentMatch{
+			EOLBit:   op&0x80 == 0x80,
+			ANDBit:   op&0x40 == 0x40,
+			NotBit:   op&0x02 == 0x02,
+			MatchBit: op&0x01 == 0x01,
+			DF:       v&0x01 == 0x01,
+			IsF:      v&0x02 == 0x02,
+			FF:       v&0x04 == 0x04,
+			LF:       v&0x08 == 0x08,
+		}
+		s.Match = append(s.Match, m)
+		eol = m.EOLBit
+	}
+
+	return s, p, nil
+}
+
+// UnmarshalJSON unmarshals a slice of bytes into a new FlowSPec FragmentSpec
+func (t *FragmentSpec) UnmarshalJSON(b []byte) error {
+	// fragmentSpec does not inherit FragmentSpec's methods which prevents UnmarshalJSON recursion
+	type fragmentSpec FragmentSpec
+	s := &fragmentSpec{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return err
+	}
+	*t = FragmentSpec(*s)
+
+	return nil
+}
+
+// MarshalJSON returns a binary representation of FlowSPec FragmentSpec
+func (t *FragmentSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SpecType uint8            `json:"type,omitempty"`
+		Match    []*FragmentMatch `json:"fragment_match,omitempty"`
+	}{
+		SpecType: t.SpecType,
+		Match:    t.Match,
+	})
+}
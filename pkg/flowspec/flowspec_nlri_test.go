@@ -18,6 +18,8 @@ func TestUnmarshalFlowspecNLRI(t *testing.T) {
 			name:  "Type 2 (Source Prefix)",
 			input: []byte{0x05, 0x02, 0x18, 0x0A, 0x00, 0x07},
 			expect: &NLRI{
+				AFI:    1,
+				SAFI:   133,
 				Length: 5,
 				Spec: []Spec{
 					&PrefixSpec{
@@ -34,6 +36,8 @@ func TestUnmarshalFlowspecNLRI(t *testing.T) {
 			name:  "Type 3 (IP Protocol)",
 			input: []byte{0x03, 0x03, 0x81, 0x2F},
 			expect: &NLRI{
+				AFI:    1,
+				SAFI:   133,
 				Length: 3,
 				Spec: []Spec{
 					&GenericSpec{
@@ -54,6 +58,65 @@ func TestUnmarshalFlowspecNLRI(t *testing.T) {
 			},
 			fail: false,
 		},
+		{
+			name:  "Type 10 (Packet Length) range",
+			input: []byte{0x07, 0x0a, 0x13, 0x00, 0x64, 0xd5, 0x05, 0xdc},
+			expect: &NLRI{
+				AFI:    1,
+				SAFI:   133,
+				Length: 7,
+				Spec: []Spec{
+					&GenericSpec{
+						SpecType: 10,
+						OpVal: []*OpVal{
+							{
+								Op: &Operator{
+									Length: 2,
+									GTBit:  true,
+									EQBit:  true,
+								},
+								Val: []byte{0x00, 0x64},
+							},
+							{
+								Op: &Operator{
+									EOLBit: true,
+									ANDBit: true,
+									Length: 2,
+									LTBit:  true,
+									EQBit:  true,
+								},
+								Val: []byte{0x05, 0xdc},
+							},
+						},
+					},
+				},
+				SpecHash: "ebd8ff2f3c1f00616d72654489de95a4",
+			},
+			fail: false,
+		},
+		{
+			name:  "Type 12 (Fragment) is fragment",
+			input: []byte{0x03, 0x0c, 0x81, 0x02},
+			expect: &NLRI{
+				AFI:    1,
+				SAFI:   133,
+				Length: 3,
+				Spec: []Spec{
+					&FragmentSpec{
+						SpecType: 12,
+						Match: []*FragmentMatch{
+							{
+								EOLBit:   true,
+								MatchBit: true,
+								IsF:      true,
+							},
+						},
+					},
+				},
+				SpecHash: "37eaa13f144180182435ce948e916c5a",
+			},
+			fail: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -68,3 +131,409 @@ func TestUnmarshalFlowspecNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestOpValGetValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []uint64
+	}{
+		{
+			name:   "packet length between 100 and 1500",
+			input:  []byte{0x07, 0x0a, 0x13, 0x00, 0x64, 0xd5, 0x05, 0xdc},
+			expect: []uint64{100, 1500},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalFlowspecNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			spec, ok := nlri.Spec[0].(*GenericSpec)
+			if !ok {
+				t.Fatalf("expected generic spec, got %T", nlri.Spec[0])
+			}
+			got := make([]uint64, 0)
+			for _, ov := range spec.OpVal {
+				got = append(got, ov.GetValue())
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected values %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFlowspecNLRILongLength(t *testing.T) {
+	// Type 3 (IP Protocol) spec with 125 Operator/Value pairs, 251 bytes in total
+	pairs := 125
+	spec := []byte{0x03}
+	for i := 0; i < pairs; i++ {
+		op := byte(0x01)
+		if i == pairs-1 {
+			op = 0x81
+		}
+		spec = append(spec, op, byte(i))
+	}
+	input := append([]byte{0xf0, byte(len(spec))}, spec...)
+	got, err := UnmarshalFlowspecNLRI(input)
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	if int(got.Length) != len(spec) {
+		t.Fatalf("expected NLRI length %d, got %d", len(spec), got.Length)
+	}
+	if len(got.Spec) != 1 {
+		t.Fatalf("expected 1 spec, got %d", len(got.Spec))
+	}
+	gs, ok := got.Spec[0].(*GenericSpec)
+	if !ok {
+		t.Fatalf("expected generic spec, got %T", got.Spec[0])
+	}
+	if len(gs.OpVal) != pairs {
+		t.Fatalf("expected %d Operator/Value pairs, got %d", pairs, len(gs.OpVal))
+	}
+	if v := gs.OpVal[pairs-1].GetValue(); v != uint64(pairs-1) {
+		t.Fatalf("expected last value %d, got %d", pairs-1, v)
+	}
+}
+
+func TestUnmarshalFlowspecNLRINumericSpecs(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect map[SpecType][]string
+	}{
+		{
+			name:  "Type 11 (DSCP) af11 or ef",
+			input: []byte{0x05, 0x0b, 0x01, 0x0a, 0x81, 0x2e},
+			expect: map[SpecType][]string{
+				Type11: {"==10", "==46"},
+			},
+		},
+		{
+			name:  "Type 7 (ICMP type) echo request and Type 8 (ICMP code) 0",
+			input: []byte{0x06, 0x07, 0x81, 0x08, 0x08, 0x81, 0x00},
+			expect: map[SpecType][]string{
+				Type7: {"==8"},
+				Type8: {"==0"},
+			},
+		},
+		{
+			name:  "Type 7 (ICMP type) range between 3 and 5",
+			input: []byte{0x05, 0x07, 0x03, 0x03, 0xc5, 0x05},
+			expect: map[SpecType][]string{
+				Type7: {">=3", "&&<=5"},
+			},
+		},
+		{
+			name:  "Type 13 (Flow Label) not equal",
+			input: []byte{0x06, 0x0d, 0xa6, 0x00, 0x01, 0x23, 0x45},
+			expect: map[SpecType][]string{
+				Type13: {"!=74565"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalFlowspecNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			got := make(map[SpecType][]string)
+			for _, s := range nlri.Spec {
+				gs, ok := s.(*GenericSpec)
+				if !ok {
+					t.Fatalf("expected generic spec, got %T", s)
+				}
+				for _, ov := range gs.OpVal {
+					got[SpecType(gs.SpecType)] = append(got[SpecType(gs.SpecType)], ov.String())
+				}
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected specs %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFlowspecNLRITCPFlags(t *testing.T) {
+	// Match SYN set and not ACK
+	input := []byte{0x05, 0x09, 0x01, 0x02, 0xc2, 0x10}
+	expect := []Spec{
+		&TCPFlagsSpec{
+			SpecType: 9,
+			Match: []*TCPFlagsMatch{
+				{
+					MatchBit: true,
+					Flags:    0x02,
+				},
+				{
+					EOLBit: true,
+					ANDBit: true,
+					NotBit: true,
+					Flags:  0x10,
+				},
+			},
+		},
+	}
+	got, err := UnmarshalFlowspecNLRI(input)
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	if !reflect.DeepEqual(expect, got.Spec) {
+		t.Logf("Diffs: %+v", deep.Equal(expect, got.Spec))
+		t.Fatal("expected TCP flags spec does not match unmarshaled spec")
+	}
+}
+
+func TestUnmarshalVPNFlowspecNLRI(t *testing.T) {
+	// Length 16 covers RD 65000:100 followed by Destination Prefix 10.0.1.0/24 and IP Protocol == 17
+	input := []byte{
+		0x10,
+		0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+		0x01, 0x18, 0x0a, 0x00, 0x01,
+		0x03, 0x81, 0x11,
+	}
+	nlri, err := UnmarshalVPNFlowspecNLRI(input)
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	if nlri.RD == nil || nlri.RD.String() != "65000:100" {
+		t.Fatalf("expected rd 65000:100, got %+v", nlri.RD)
+	}
+	expect := []Spec{
+		&PrefixSpec{SpecType: 1, PrefixLength: 24, Prefix: []byte{10, 0, 1}},
+		&GenericSpec{SpecType: 3, OpVal: []*OpVal{{Op: &Operator{EOLBit: true, Length: 1, EQBit: true}, Val: []byte{17}}}},
+	}
+	if !reflect.DeepEqual(expect, nlri.Spec) {
+		t.Logf("differences: %+v", deep.Equal(expect, nlri.Spec))
+		t.Fatal("the expected specs do not match the actual")
+	}
+	// The same rule without RD must produce a different hash
+	fs, err := UnmarshalFlowspecNLRI(append([]byte{0x08}, input[9:]...))
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	if fs.RD != nil {
+		t.Fatalf("expected no rd, got %+v", fs.RD)
+	}
+	if fs.SpecHash == nlri.SpecHash {
+		t.Fatal("expected vpn flowspec hash to differ from flowspec hash")
+	}
+}
+
+func TestTCPFlagsMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		flags  []TCPFlags
+		expect []string
+	}{
+		{
+			name:   "match syn and ack",
+			input:  []byte{0x03, 0x09, 0x81, 0x12},
+			flags:  []TCPFlags{{SYN: true, ACK: true}},
+			expect: []string{"=ACK|SYN"},
+		},
+		{
+			name:   "match syn only",
+			input:  []byte{0x05, 0x09, 0x01, 0x02, 0xc2, 0x10},
+			flags:  []TCPFlags{{SYN: true}, {ACK: true}},
+			expect: []string{"=SYN", "&&!ACK"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := UnmarshalFlowspecNLRI(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			s, ok := nlri.Spec[0].(*TCPFlagsSpec)
+			if !ok {
+				t.Fatalf("expected tcp flags spec, got %T", nlri.Spec[0])
+			}
+			flags := make([]TCPFlags, 0)
+			got := make([]string, 0)
+			for _, m := range s.Match {
+				flags = append(flags, m.GetTCPFlags())
+				got = append(got, m.String())
+			}
+			if !reflect.DeepEqual(tt.flags, flags) {
+				t.Fatalf("expected flags %+v, got %+v", tt.flags, flags)
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected matches %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestCanonicalKey(t *testing.T) {
+	// Destination 10.0.0.0/24, IP Protocol == 6, Destination Port == 80
+	ordered := []byte{0x0b, 0x01, 0x18, 0x0a, 0x00, 0x00, 0x03, 0x81, 0x06, 0x05, 0x81, 0x50}
+	// The same components listed as Destination Port, Destination Prefix and IP Protocol
+	reordered := []byte{0x0b, 0x05, 0x81, 0x50, 0x01, 0x18, 0x0a, 0x00, 0x00, 0x03, 0x81, 0x06}
+	// Destination 10.0.0.0/24, IP Protocol == 17, Destination Port == 80
+	different := []byte{0x0b, 0x01, 0x18, 0x0a, 0x00, 0x00, 0x03, 0x81, 0x11, 0x05, 0x81, 0x50}
+	fs1, err := UnmarshalFlowspecNLRI(ordered)
+	if err != nil {
+		t.Fatalf("failed to unmarshal flowspec nlri with error: %+v", err)
+	}
+	fs2, err := UnmarshalFlowspecNLRI(reordered)
+	if err != nil {
+		t.Fatalf("failed to unmarshal flowspec nlri with error: %+v", err)
+	}
+	fs3, err := UnmarshalFlowspecNLRI(different)
+	if err != nil {
+		t.Fatalf("failed to unmarshal flowspec nlri with error: %+v", err)
+	}
+	if fs1.SpecHash == fs2.SpecHash {
+		t.Fatal("expected spec hash to depend on the order of components")
+	}
+	if fs1.CanonicalKey() != fs2.CanonicalKey() {
+		t.Fatalf("expected equal canonical keys, got %s and %s", fs1.CanonicalKey(), fs2.CanonicalKey())
+	}
+	if fs1.CanonicalKey() == fs3.CanonicalKey() {
+		t.Fatal("expected different canonical keys for different rules")
+	}
+	// Original order of components must be preserved
+	if ps, ok := fs2.Spec[0].(*GenericSpec); !ok || ps.SpecType != 5 {
+		t.Fatalf("expected the first component of type 5, got %+v", fs2.Spec[0])
+	}
+}
+
+func TestNLRIString(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		unmarshal func([]byte) (*NLRI, error)
+		expect    string
+	}{
+		{
+			name:      "destination prefix, protocol and destination port",
+			input:     []byte{0x0b, 0x01, 0x18, 0x0a, 0x00, 0x00, 0x03, 0x81, 0x06, 0x05, 0x81, 0x50},
+			unmarshal: UnmarshalFlowspecNLRI,
+			expect:    "dst 10.0.0.0/24, proto TCP, dst-port 80",
+		},
+		{
+			name: "source prefix, ports, tcp flags, packet length range and fragment",
+			input: []byte{
+				0x1b,
+				0x02, 0x20, 0xc0, 0xa8, 0x00, 0x01,
+				0x05, 0x01, 0x50, 0x91, 0x01, 0xbb,
+				0x09, 0x01, 0x02, 0xc2, 0x10,
+				0x0a, 0x13, 0x00, 0x64, 0xd5, 0x05, 0xdc,
+				0x0c, 0x81, 0x02,
+			},
+			unmarshal: UnmarshalFlowspecNLRI,
+			expect:    "src 192.168.0.1/32, dst-port 80 || 443, tcp-flags =SYN && !ACK, pkt-len >=100 && <=1500, fragment =IsF",
+		},
+		{
+			name: "vpn destination prefix and protocol",
+			input: []byte{
+				0x10,
+				0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64,
+				0x01, 0x18, 0x0a, 0x00, 0x01,
+				0x03, 0x81, 0x11,
+			},
+			unmarshal: UnmarshalVPNFlowspecNLRI,
+			expect:    "rd 65000:100, dst 10.0.1.0/24, proto UDP",
+		},
+		{
+			name:      "ipv6 destination prefix with offset and next header",
+			input:     []byte{0x0a, 0x01, 0x40, 0x20, 0x00, 0x01, 0x00, 0x02, 0x03, 0x81, 0x3a},
+			unmarshal: UnmarshalIPv6FlowspecNLRI,
+			expect:    "dst 0:0:1:2::/64 offset 32, proto ICMPv6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nlri, err := tt.unmarshal(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			if got := nlri.String(); got != tt.expect {
+				t.Fatalf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestNLRIPortComponents(t *testing.T) {
+	// Port == 179, Destination Port == 80 or 443, Source Port >= 1024
+	input := []byte{0x0e, 0x04, 0x91, 0x00, 0xb3, 0x05, 0x01, 0x50, 0x91, 0x01, 0xbb, 0x06, 0x93, 0x04, 0x00}
+	nlri, err := UnmarshalFlowspecNLRI(input)
+	if err != nil {
+		t.Fatalf("failed with error: %+v", err)
+	}
+	tests := []struct {
+		spec   SpecType
+		expect string
+	}{
+		{spec: Type4, expect: "179"},
+		{spec: Type5, expect: "80 || 443"},
+		{spec: Type6, expect: ">=1024"},
+	}
+	for _, tt := range tests {
+		s, ok := nlri.GetGenericSpec(tt.spec)
+		if !ok {
+			t.Fatalf("expected %s component", tt.spec)
+		}
+		if got := s.String(); got != tt.expect {
+			t.Fatalf("expected %s component %q, got %q", tt.spec, tt.expect, got)
+		}
+	}
+	if _, ok := nlri.GetGenericSpec(Type3); ok {
+		t.Fatal("expected no ip protocol component")
+	}
+	if s := nlri.String(); s != "port 179, dst-port 80 || 443, src-port >=1024" {
+		t.Fatalf("unexpected rule %q", s)
+	}
+}
+
+func TestGenericSpecIPProtocol(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect string
+	}{
+		{
+			name:   "tcp",
+			input:  []byte{0x03, 0x81, 0x06},
+			expect: "TCP",
+		},
+		{
+			name:   "tcp or udp",
+			input:  []byte{0x03, 0x01, 0x06, 0x81, 0x11},
+			expect: "TCP || UDP",
+		},
+		{
+			name:   "not gre",
+			input:  []byte{0x03, 0x86, 0x2f},
+			expect: "!=GRE",
+		},
+		{
+			name:   "range is numeric",
+			input:  []byte{0x03, 0x83, 0x06},
+			expect: ">=6",
+		},
+		{
+			name:   "unknown protocol",
+			input:  []byte{0x03, 0x81, 0xfd},
+			expect: "253",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, err := makeGenericSpec(tt.input)
+			if err != nil {
+				t.Fatalf("failed with error: %+v", err)
+			}
+			if got := s.(*GenericSpec).String(); got != tt.expect {
+				t.Fatalf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
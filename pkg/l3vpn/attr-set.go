@@ -0,0 +1,142 @@
+package l3vpn
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/sbezverk/tools"
+)
+
+// AttrSetAttribute defines a raw path attribute carried inside of ATTR_SET attribute
+type AttrSetAttribute struct {
+	Flags uint8
+	Type  uint8
+	Value []byte
+}
+
+// AttrSet defines BGP ATTR_SET attribute (128) object, PE uses it to carry path attributes received
+// from CE across the provider network, so they can be restored on the remote PE.
+// https://www.rfc-editor.org/rfc/rfc6368#section-5
+type AttrSet struct {
+	// OriginAS is the AS of the customer network the attributes were received from
+	OriginAS uint32
+	// Origin is the value of ORIGIN attribute as received from CE, empty when not present
+	Origin string
+	// ASPath is the AS_PATH as received from CE, ASNs are encoded with 4 bytes
+	ASPath     []uint32
+	Attributes []AttrSetAttribute
+}
+
+// GetEffectiveOriginAS returns the AS which originated the route from CE perspective, it is the last AS
+// in CE's AS_PATH, if CE's AS_PATH is empty, the route was originated by the customer network itself.
+func (a *AttrSet) GetEffectiveOriginAS() uint32 {
+	if len(a.ASPath) == 0 {
+		return a.OriginAS
+	}
+
+	return a.ASPath[len(a.ASPath)-1]
+}
+
+// GetAttribute returns the value of the inner attribute of type t and true, false is returned if
+// the attribute is not present.
+func (a *AttrSet) GetAttribute(t uint8) ([]byte, bool) {
+	for _, attr := range a.Attributes {
+		if attr.Type == t {
+			return attr.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// UnmarshalAttrSet builds ATTR_SET attribute object
+func UnmarshalAttrSet(b []byte) (*AttrSet, error) {
+	if glog.V(6) {
+		glog.Infof("ATTR_SET Raw: %s", tools.MessageHex(b))
+	}
+	if len(b) < 4 {
+		return nil, fmt.Errorf("not enough bytes to unmarshal attr_set")
+	}
+	a := &AttrSet{
+		OriginAS:   binary.BigEndian.Uint32(b[0:4]),
+		ASPath:     make([]uint32, 0),
+		Attributes: make([]AttrSetAttribute, 0),
+	}
+	for p := 4; p < len(b); {
+		if p+3 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal attr_set path attribute")
+		}
+		attr := AttrSetAttribute{
+			Flags: b[p],
+			Type:  b[p+1],
+		}
+		p += 2
+		l := int(b[p])
+		p++
+		// Extended Length bit
+		if attr.Flags&0x10 == 0x10 {
+			if p+1 > len(b) {
+				return nil, fmt.Errorf("not enough bytes to unmarshal attr_set path attribute %d", attr.Type)
+			}
+			l = l<<8 | int(b[p])
+			p++
+		}
+		if p+l > len(b) {
+			return nil, fmt.Errorf("invalid attr_set path attribute %d length %d", attr.Type, l)
+		}
+		attr.Value = make([]byte, l)
+		copy(attr.Value, b[p:p+l])
+		switch attr.Type {
+		case 1:
+			if l != 1 {
+				return nil, fmt.Errorf("invalid attr_set origin length %d", l)
+			}
+			a.Origin = unmarshalOrigin(attr.Value[0])
+		case 2:
+			path, err := unmarshalAS4Path(attr.Value)
+			if err != nil {
+				return nil, err
+			}
+			a.ASPath = path
+		}
+		a.Attributes = append(a.Attributes, attr)
+		p += l
+	}
+
+	return a, nil
+}
+
+func unmarshalOrigin(o byte) string {
+	switch o {
+	case 0:
+		return "igp"
+	case 1:
+		return "egp"
+	case 2:
+		return "incomplete"
+	}
+
+	return ""
+}
+
+func unmarshalAS4Path(b []byte) ([]uint32, error) {
+	path := make([]uint32, 0)
+	for p := 0; p < len(b); {
+		if p+2 > len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal attr_set as path segment")
+		}
+		// Skipping segment type
+		l := int(b[p+1])
+		p += 2
+		if p+l*4 > len(b) {
+			return nil, fmt.Errorf("invalid attr_set as path segment length %d", l)
+		}
+		for n := 0; n < l; n++ {
+			path = append(path, binary.BigEndian.Uint32(b[p:p+4]))
+			p += 4
+		}
+	}
+
+	return path, nil
+}
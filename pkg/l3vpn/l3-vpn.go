@@ -22,6 +22,24 @@ func UnmarshalL3VPNNLRI(b []byte, pathID bool, srv6 ...bool) (*base.MPNLRI, erro
 	if len(b) == 0 {
 		return nil, fmt.Errorf("NLRI length is 0")
 	}
+	mpnlri, err := unmarshalL3VPNNLRI(b, pathID, srv6Flag)
+	if err != nil {
+		// In some cases, Error could be triggered by use of incorrect value of PathID flag, as Add Path capability
+		// might be advertised and received, but BGP Update would not have PathID set due to some other conditions,
+		// example when bgp speakers are in different AS. In error handle, attempting to Unmarshal again with reversed
+		// value of PathID flag.
+		if mp, e := unmarshalL3VPNNLRI(b, !pathID, srv6Flag); e == nil {
+			return mp, nil
+		}
+		glog.Errorf("failed to reconstruct l3vpn nlri from slice %s with error: %+v", tools.MessageHex(b), err)
+
+		return nil, err
+	}
+
+	return mpnlri, nil
+}
+
+func unmarshalL3VPNNLRI(b []byte, pathID bool, srv6Flag bool) (*base.MPNLRI, error) {
 	mpnlri := base.MPNLRI{
 		NLRI: make([]base.Route, 0),
 	}
@@ -38,12 +56,12 @@ func UnmarshalL3VPNNLRI(b []byte, pathID bool, srv6 ...bool) (*base.MPNLRI, erro
 			up.PathID = binary.BigEndian.Uint32(b[p : p+4])
 			p += 4
 		}
-		up.Length = b[p]
-		if up.Length <= 0 {
+		if p+1 > len(b) {
 			err = fmt.Errorf("not enough bytes to reconstruct l3vpn nlri")
 			goto error_handle
 		}
-		if p+1 > len(b) {
+		up.Length = b[p]
+		if up.Length <= 0 {
 			err = fmt.Errorf("not enough bytes to reconstruct l3vpn nlri")
 			goto error_handle
 		}
@@ -95,13 +113,14 @@ func UnmarshalL3VPNNLRI(b []byte, pathID bool, srv6 ...bool) (*base.MPNLRI, erro
 		p += 8
 		up.RD = rd
 		// Adjusting prefix length to remove bits used by labels each label takes 3 bytes, or 3 bytes
-		// of Compatibility field
-		l := int(up.Length/8) - (len(up.Label) * 3) - compatibilityField - 8
-		if l < 0 {
+		// of Compatibility field, and 8 bytes of RD, the remaining bits are the IP prefix length.
+		pl := int(up.Length) - (len(up.Label)*3+compatibilityField+8)*8
+		if pl < 0 {
 			err = fmt.Errorf("not enough bytes to reconstruct l3vpn nlri")
 			goto error_handle
 		}
-		if up.Length%8 != 0 {
+		l := pl / 8
+		if pl%8 != 0 {
 			l++
 		}
 		if p+l > len(b) {
@@ -111,21 +130,12 @@ func UnmarshalL3VPNNLRI(b []byte, pathID bool, srv6 ...bool) (*base.MPNLRI, erro
 		up.Prefix = make([]byte, l)
 		copy(up.Prefix, b[p:p+l])
 		p += l
-		up.Length = uint8(l * 8)
+		up.Length = uint8(pl)
 		mpnlri.NLRI = append(mpnlri.NLRI, up)
 	}
 
 error_handle:
 	if err != nil {
-		// In some cases, Error could be triggered by use of incorrect value of PathID flag, as Add Path capability
-		// might be advertised and received, but BGP Update would not have PathID set due to some other conditions,
-		// example when bgp speakers are in different AS. In error handle, attempting to Unmarshal again with reversed
-		// value of PathID flag.
-		if mp, e := UnmarshalL3VPNNLRI(b, !pathID, srv6Flag); e == nil {
-			return mp, nil
-		}
-		glog.Errorf("failed to reconstruct l3vpn nlri from slice %s with error: %+v", tools.MessageHex(b), err)
-
 		return nil, err
 	}
 
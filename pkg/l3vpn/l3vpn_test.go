@@ -154,7 +154,7 @@ func TestUnmarshalL3VPNNLRI(t *testing.T) {
 			expect: &base.MPNLRI{
 				NLRI: []base.Route{
 					{
-						Length: 32,
+						Length: 30,
 						Label: []*base.Label{
 							{
 								Value: 16896,
@@ -206,7 +206,7 @@ func TestUnmarshalL3VPNNLRI(t *testing.T) {
 			expect: &base.MPNLRI{
 				NLRI: []base.Route{
 					{
-						Length: 32,
+						Length: 31,
 						Label: []*base.Label{
 							{
 								Value: 24019,
@@ -260,3 +260,138 @@ func TestUnmarshalL3VPNNLRI(t *testing.T) {
 		})
 	}
 }
+
+func TestL3VPNPrefixLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		labels int
+		length uint8
+		prefix []byte
+	}{
+		{
+			name:   "1 label /25",
+			input:  []byte{0x71, 0x05, 0xdc, 0x41, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64, 0x0a, 0x00, 0x00, 0x80},
+			labels: 1,
+			length: 25,
+			prefix: []byte{0x0a, 0x00, 0x00, 0x80},
+		},
+		{
+			name:   "2 labels /25",
+			input:  []byte{0x89, 0x05, 0xdc, 0x40, 0x05, 0xdc, 0x51, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64, 0x0a, 0x00, 0x00, 0x80},
+			labels: 2,
+			length: 25,
+			prefix: []byte{0x0a, 0x00, 0x00, 0x80},
+		},
+		{
+			name:   "2 labels /32",
+			input:  []byte{0x90, 0x05, 0xdc, 0x40, 0x05, 0xdc, 0x51, 0x00, 0x00, 0x00, 0x64, 0x00, 0x00, 0x00, 0x64, 0x0a, 0x00, 0x00, 0x01},
+			labels: 2,
+			length: 32,
+			prefix: []byte{0x0a, 0x00, 0x00, 0x01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalL3VPNNLRI(tt.input, false)
+			if err != nil {
+				t.Fatalf("failed to unmarshal l3vpn nlri with error: %+v", err)
+			}
+			if len(got.NLRI) != 1 {
+				t.Fatalf("expected 1 route, got %d", len(got.NLRI))
+			}
+			r := got.NLRI[0]
+			if len(r.Label) != tt.labels {
+				t.Fatalf("expected %d labels, got %d", tt.labels, len(r.Label))
+			}
+			if r.Length != tt.length {
+				t.Fatalf("expected prefix length %d, got %d", tt.length, r.Length)
+			}
+			if !reflect.DeepEqual(tt.prefix, r.Prefix) {
+				t.Fatalf("expected prefix %+v, got %+v", tt.prefix, r.Prefix)
+			}
+		})
+	}
+}
+
+func TestUnmarshalAttrSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expect   *AttrSet
+		originAS uint32
+		fail     bool
+	}{
+		{
+			name: "ce imported route",
+			// Origin AS 65001, ORIGIN igp, AS_PATH 65001 65100, MED 10
+			input: []byte{
+				0x00, 0x00, 0xfd, 0xe9,
+				0x40, 0x01, 0x01, 0x00,
+				0x40, 0x02, 0x0a, 0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfe, 0x4c,
+				0x80, 0x04, 0x04, 0x00, 0x00, 0x00, 0x0a,
+			},
+			expect: &AttrSet{
+				OriginAS: 65001,
+				Origin:   "igp",
+				ASPath:   []uint32{65001, 65100},
+				Attributes: []AttrSetAttribute{
+					{Flags: 0x40, Type: 1, Value: []byte{0x00}},
+					{Flags: 0x40, Type: 2, Value: []byte{0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9, 0x00, 0x00, 0xfe, 0x4c}},
+					{Flags: 0x80, Type: 4, Value: []byte{0x00, 0x00, 0x00, 0x0a}},
+				},
+			},
+			originAS: 65100,
+		},
+		{
+			name: "route originated by customer network",
+			// Origin AS 65001, ORIGIN incomplete, empty AS_PATH with extended length
+			input: []byte{
+				0x00, 0x00, 0xfd, 0xe9,
+				0x40, 0x01, 0x01, 0x02,
+				0x50, 0x02, 0x00, 0x00,
+			},
+			expect: &AttrSet{
+				OriginAS: 65001,
+				Origin:   "incomplete",
+				ASPath:   []uint32{},
+				Attributes: []AttrSetAttribute{
+					{Flags: 0x40, Type: 1, Value: []byte{0x02}},
+					{Flags: 0x50, Type: 2, Value: []byte{}},
+				},
+			},
+			originAS: 65001,
+		},
+		{
+			name:  "truncated origin as",
+			input: []byte{0x00, 0x00, 0xfd},
+			fail:  true,
+		},
+		{
+			name:  "truncated as path",
+			input: []byte{0x00, 0x00, 0xfd, 0xe9, 0x40, 0x02, 0x06, 0x02, 0x02, 0x00, 0x00, 0xfd, 0xe9},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalAttrSet(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("expected to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("expected to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("Diffs: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected attr_set does not match the actual")
+			}
+			if as := got.GetEffectiveOriginAS(); as != tt.originAS {
+				t.Fatalf("expected effective origin as %d, got %d", tt.originAS, as)
+			}
+		})
+	}
+}
@@ -35,34 +35,66 @@ func (p *producer) produceStatsMessage(msg bmp.Message) {
 	}
 	m.RemoteIP = msg.PeerHeader.GetPeerAddrString()
 	m.RemoteBGPID = msg.PeerHeader.GetPeerBGPIDString()
-	for _, tlv := range StatsMsg.StatsTLV {
+	decodeStats(&m, StatsMsg.StatsTLV)
+	if err := p.marshalAndPublish(&m, bmp.StatsReportMsg, []byte(m.RouterHash), false); err != nil {
+		glog.Errorf("failed to process peer Stats Report message with error: %+v", err)
+		return
+	}
+}
+
+// decodeStats populates Stats message with values of known stat types, stats of unknown types
+// and stats with unexpected value length are kept in Unknown.
+// https://www.iana.org/assignments/bmp-parameters/bmp-parameters.xhtml#statistics-types
+func decodeStats(m *Stats, tlvs []bmp.InformationalTLV) {
+	for _, tlv := range tlvs {
+		var u32 *uint32
+		var u64 *uint64
+		var afisafi *[]AFISAFIStat
 		switch tlv.InformationType {
 		case 1:
-			m.DuplicatePrefixs = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.DuplicatePrefixs
 		case 2:
-			m.DuplicateWithDraws = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.DuplicateWithDraws
 		case 3:
-			m.InvalidatedDueCluster = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.InvalidatedDueCluster
 		case 4:
-			m.InvalidatedDueAspath = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.InvalidatedDueAspath
 		case 5:
-			m.InvalidatedDueOriginatorId = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.InvalidatedDueOriginatorId
 		case 6:
-			m.InvalidatedAsConfed = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.InvalidatedAsConfed
 		case 7:
-			m.AdjRIBsIn = binary.BigEndian.Uint64(tlv.Information)
+			u64 = &m.AdjRIBsIn
 		case 8:
-			m.LocalRib = binary.BigEndian.Uint64(tlv.Information)
+			u64 = &m.LocalRib
+		case 9:
+			afisafi = &m.PerAFISAFIAdjRIBsIn
+		case 10:
+			afisafi = &m.PerAFISAFILocalRib
 		case 11:
-			m.UpdatesAsWithdraw = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.UpdatesAsWithdraw
 		case 12:
-			m.PrefixesAsWithdraw = binary.BigEndian.Uint32(tlv.Information)
+			u32 = &m.PrefixesAsWithdraw
+		case 13:
+			u32 = &m.DuplicateUpdates
+		}
+		switch {
+		case u32 != nil && len(tlv.Information) == 4:
+			*u32 = binary.BigEndian.Uint32(tlv.Information)
+		case u64 != nil && len(tlv.Information) == 8:
+			*u64 = binary.BigEndian.Uint64(tlv.Information)
+		case afisafi != nil && len(tlv.Information) == 11:
+			// AFI 2 bytes, SAFI 1 byte followed by 64 bit gauge
+			*afisafi = append(*afisafi, AFISAFIStat{
+				AFI:   binary.BigEndian.Uint16(tlv.Information[0:2]),
+				SAFI:  tlv.Information[2],
+				Value: binary.BigEndian.Uint64(tlv.Information[3:]),
+			})
 		default:
 			glog.Warningf("unprocessed stats type:%v", tlv.InformationType)
+			v := make([]byte, len(tlv.Information))
+			copy(v, tlv.Information)
+			m.Unknown = append(m.Unknown, RawStat{Type: tlv.InformationType, Value: v})
 		}
 	}
-	if err := p.marshalAndPublish(&m, bmp.StatsReportMsg, []byte(m.RouterHash), false); err != nil {
-		glog.Errorf("failed to process peer Stats Report message with error: %+v", err)
-		return
-	}
 }
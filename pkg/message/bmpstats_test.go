@@ -0,0 +1,78 @@
+package message
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/bmp"
+)
+
+func TestDecodeStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *Stats
+	}{
+		{
+			name: "duplicate updates and unknown type 99",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x03,
+				0x00, 0x02, 0x00, 0x04, 0x00, 0x00, 0x00, 0x05,
+				0x00, 0x0d, 0x00, 0x04, 0x00, 0x00, 0x00, 0x07,
+				0x00, 0x63, 0x00, 0x02, 0xab, 0xcd,
+			},
+			expect: &Stats{
+				DuplicateWithDraws: 5,
+				DuplicateUpdates:   7,
+				Unknown: []RawStat{
+					{Type: 99, Value: []byte{0xab, 0xcd}},
+				},
+			},
+		},
+		{
+			name: "known type with invalid length",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00,
+				0x00, 0x01, 0x00, 0x02, 0x00, 0x01,
+			},
+			expect: &Stats{
+				AdjRIBsIn: 256,
+				Unknown: []RawStat{
+					{Type: 1, Value: []byte{0x00, 0x01}},
+				},
+			},
+		},
+		{
+			name: "loc-rib routes and per afi/safi loc-rib routes",
+			input: []byte{
+				0x00, 0x00, 0x00, 0x03,
+				0x00, 0x08, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0f, 0x42, 0x40,
+				0x00, 0x0a, 0x00, 0x0b, 0x00, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0d, 0xbb, 0xa0,
+				0x00, 0x0a, 0x00, 0x0b, 0x00, 0x02, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x86, 0xa0,
+			},
+			expect: &Stats{
+				LocalRib: 1000000,
+				PerAFISAFILocalRib: []AFISAFIStat{
+					{AFI: 1, SAFI: 1, Value: 900000},
+					{AFI: 2, SAFI: 1, Value: 100000},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr, err := bmp.UnmarshalBMPStatsReportMessage(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal stats report with error: %+v", err)
+			}
+			got := &Stats{}
+			decodeStats(got, sr.StatsTLV)
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected stats do not match the actual")
+			}
+		})
+	}
+}
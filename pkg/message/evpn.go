@@ -56,39 +56,41 @@ func (p *producer) evpn(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, update *
 		if e != nil {
 			prfx.VPNRD = e.GetEVPNRD()
 			prfx.RouteType = e.GetEVPNRouteType()
-			esi := e.GetEVPNESI()
-			if esi != nil {
-				// TODO Change 10 for a const for ESI length
-				for i := 0; i < 10; i++ {
-					prfx.ESI += fmt.Sprintf("%02d", esi[i])
-					// TODO same here ESI length -1
-					if i < 9 {
-						prfx.ESI += ":"
-					}
-				}
+			if esi := e.GetEVPNESI(); esi != nil {
+				prfx.ESI = esi.String()
 			}
 			prfx.EthTag = e.GetEVPNTAG()
+			if prfx.RouteType == 2 {
+				prfx.DefaultGateway = update.HasDefaultGateway()
+			}
+			if vni, mac, ok := update.GetSymmetricIRB(e); ok {
+				prfx.L3VNI = vni
+				prfx.RouterMAC = mac.String()
+			}
+			if o, ok := update.GetConsistentHashSortOrder(); ok {
+				prfx.ConsistentHashSortOrder = o
+			}
+			if prfx.RouteType == 1 {
+				if l2, ok := update.GetEVPNL2Attributes(); ok {
+					prfx.L2Attributes = l2
+				}
+			}
+			if prfx.RouteType == 3 {
+				if pt, err := update.GetAttrPMSITunnel(); err == nil {
+					prfx.PMSITunnel = pt
+				}
+			}
 			if ip := e.GetEVPNIPLength(); ip != nil {
 				prfx.IPLength = *ip
 				gw := e.GetEVPNGWAddr()
 				addr := e.GetEVPNIPAddr()
-				// IPv4 should have IPLength set to 32
-				if prfx.IPLength <= 32 {
-					if addr != nil {
-						prfx.IPAddress = net.IP(addr).To4().String()
-					}
-					if gw != nil {
-						prfx.GWAddress = net.IP(gw).To4().String()
-					}
+				// IPv4 and IPv6 addresses are distinguished by the number of bytes carried in the route,
+				// as IPLength of prefix routes is the prefix length rather than the address length.
+				if addr != nil {
+					prfx.IPAddress = net.IP(addr).String()
 				}
-				// Processing IPv6 IP and GW
-				if prfx.IPLength <= 128 {
-					if addr != nil {
-						prfx.IPAddress = net.IP(addr).To16().String()
-					}
-					if gw != nil {
-						prfx.GWAddress = net.IP(gw).To16().String()
-					}
+				if gw != nil {
+					prfx.GWAddress = net.IP(gw).String()
 				}
 			}
 			if mac := e.GetEVPNMACLength(); mac != nil {
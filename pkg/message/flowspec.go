@@ -26,6 +26,10 @@ func (p *producer) flowspec(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, upda
 	if err != nil {
 		return nil, err
 	}
+	if len(fsnlri.Spec) == 0 {
+		// End-of-RIB marker does not carry any Flowspec rule
+		return []*Flowspec{}, nil
+	}
 	fs := &Flowspec{
 		Action:         operation,
 		RouterIP:       p.speakerIP,
@@ -43,6 +47,30 @@ func (p *producer) flowspec(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, upda
 
 	fs.Nexthop = nlri.GetNextHop()
 	fs.Spec = fsnlri.Spec
+	if fsnlri.RD != nil {
+		fs.VPNRD = fsnlri.RD.String()
+		fs.VPNRDType = fsnlri.RD.Type
+	}
+	if ext, err := update.GetAttrExtCommunity(); err == nil {
+		for _, e := range ext {
+			if r, ok := e.GetTrafficRateBytes(); ok {
+				fs.RateBytes = &r
+			}
+			if r, ok := e.GetTrafficRatePackets(); ok {
+				fs.RatePPS = &r
+			}
+			if d, ok := e.GetTrafficMarkingDSCP(); ok {
+				fs.RemarkDSCP = &d
+			}
+		}
+	}
+	if ext, err := update.GetAttrIPv6ExtCommunity(); err == nil {
+		for _, e := range ext {
+			if rt, ok := e.GetFlowspecRedirect(); ok {
+				fs.RedirectIPv6RT = rt
+			}
+		}
+	}
 	fs.PeerIP = ph.GetPeerAddrString()
 	fs.IsIPv4 = !nlri.IsIPv6NLRI()
 	fs.IsNexthopIPv4 = !nlri.IsNextHopIPv6()
@@ -94,13 +122,42 @@ func (fs *Flowspec) UnmarshalJSON(b []byte) error {
 	if err := json.Unmarshal(objmap["timestamp"], &o.Timestamp); err != nil {
 		return err
 	}
+	if r, ok := objmap["rate_bytes"]; ok {
+		if err := json.Unmarshal(r, &o.RateBytes); err != nil {
+			return err
+		}
+	}
+	if r, ok := objmap["rate_pps"]; ok {
+		if err := json.Unmarshal(r, &o.RatePPS); err != nil {
+			return err
+		}
+	}
+	if r, ok := objmap["remark_dscp"]; ok {
+		if err := json.Unmarshal(r, &o.RemarkDSCP); err != nil {
+			return err
+		}
+	}
+	if r, ok := objmap["vpn_rd"]; ok {
+		if err := json.Unmarshal(r, &o.VPNRD); err != nil {
+			return err
+		}
+	}
+	if r, ok := objmap["vpn_rd_type"]; ok {
+		if err := json.Unmarshal(r, &o.VPNRDType); err != nil {
+			return err
+		}
+	}
 	if s, ok := objmap["spec"]; ok {
-		var specs []map[string]interface{}
-		if err := json.Unmarshal(s, &specs); err != nil {
+		var raws []json.RawMessage
+		if err := json.Unmarshal(s, &raws); err != nil {
 			return err
 		}
 		o.Spec = make([]flowspec.Spec, 0)
-		for _, spec := range specs {
+		for _, raw := range raws {
+			var spec map[string]interface{}
+			if err := json.Unmarshal(raw, &spec); err != nil {
+				return err
+			}
 			switch flowspec.SpecType(spec["type"].(float64)) {
 			case flowspec.Type1:
 				fallthrough
@@ -110,14 +167,27 @@ func (fs *Flowspec) UnmarshalJSON(b []byte) error {
 					return err
 				}
 				o.Spec = append(o.Spec, s)
-			case flowspec.Type3:
+			case flowspec.Type3, flowspec.Type4, flowspec.Type5, flowspec.Type6, flowspec.Type7,
+				flowspec.Type8, flowspec.Type10, flowspec.Type11, flowspec.Type13:
 				s, err := makeGenericSpec(spec)
 				if err != nil {
 					return err
 				}
 				o.Spec = append(o.Spec, s)
+			case flowspec.Type9:
+				s := &flowspec.TCPFlagsSpec{}
+				if err := json.Unmarshal(raw, s); err != nil {
+					return err
+				}
+				o.Spec = append(o.Spec, s)
+			case flowspec.Type12:
+				s := &flowspec.FragmentSpec{}
+				if err := json.Unmarshal(raw, s); err != nil {
+					return err
+				}
+				o.Spec = append(o.Spec, s)
 			default:
-				glog.Errorf("Unknown type: %+v", spec["type"].(flowspec.SpecType))
+				glog.Errorf("Unknown type: %+v", spec["type"])
 			}
 		}
 	}
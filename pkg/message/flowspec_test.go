@@ -0,0 +1,43 @@
+package message
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/bgp"
+	"github.com/sbezverk/gobmp/pkg/flowspec"
+)
+
+func TestRoundTripFlowspec(t *testing.T) {
+	// TCP flags SYN and not ACK, packet is a fragment other than the first
+	nlri, err := flowspec.UnmarshalFlowspecNLRI([]byte{0x08, 0x09, 0x01, 0x02, 0xc2, 0x10, 0x0c, 0x81, 0x02})
+	if err != nil {
+		t.Fatalf("failed to unmarshal flowspec nlri with error: %+v", err)
+	}
+	original := &Flowspec{
+		Action:         "add",
+		RouterIP:       "192.168.80.103",
+		BaseAttributes: &bgp.BaseAttributes{Origin: "igp"},
+		PeerASN:        65001,
+		Timestamp:      "Oct 14 17:05:47.442540",
+		IsIPv4:         true,
+		Nexthop:        "10.0.0.1",
+		IsNexthopIPv4:  true,
+		SpecHash:       nlri.GetSpecHash(),
+		Spec:           nlri.Spec,
+	}
+	b, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("TestRoundTripFlowspec Marshal failed with error: %+v but supposed to succeed", err)
+	}
+	recovered := &Flowspec{}
+	if err := json.Unmarshal(b, recovered); err != nil {
+		t.Fatalf("TestRoundTripFlowspec Unmarshal failed with error: %+v but supposed to succeed", err)
+	}
+	if !reflect.DeepEqual(original, recovered) {
+		t.Logf("Differences: %+v", deep.Equal(original, recovered))
+		t.Fatalf("TestRoundTripFlowspec failed as original %+v does not match recovered: %+v", *original, *recovered)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/golang/glog"
+	"github.com/sbezverk/gobmp/pkg/base"
 	"github.com/sbezverk/gobmp/pkg/bgp"
 	"github.com/sbezverk/gobmp/pkg/bmp"
+	"github.com/sbezverk/gobmp/pkg/srv6"
 )
 
 // l3vpn process MP_REACH_NLRI AFI 1/2 SAFI 128 update message and returns
@@ -75,11 +78,38 @@ func (p *producer) l3vpn(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, update
 		}
 		prfx.VPNRD = e.RD.String()
 		prfx.VPNRDType = e.RD.Type
+		prfx.SiteOfOrigin = update.GetSiteOfOrigin()
 		if psid, err := update.GetAttrPrefixSID(); err == nil {
 			prfx.PrefixSID = psid
+			if psid.SRv6L3Service != nil {
+				srv6L3Service(&prfx, psid.SRv6L3Service, e.Label)
+			}
 		}
 		prfxs = append(prfxs, prfx)
 	}
 
 	return prfxs, nil
 }
+
+// srv6L3Service populates SRv6 SID of L3VPN prefix from SRv6 L3 Service TLV, when transposition is used,
+// SID's bits carried in the label field of NLRI are placed back into the SID.
+func srv6L3Service(prfx *L3VPNPrefix, l3s *srv6.L3Service, labels []*base.Label) {
+	info, ok := l3s.GetInformationSubTLV()
+	if !ok {
+		return
+	}
+	sid := info.SID
+	if len(labels) != 0 {
+		s, err := info.TransposeSID(labels[0].Value)
+		if err != nil {
+			glog.Errorf("failed to transpose srv6 sid %s with error: %+v", info.SID, err)
+		} else {
+			sid = s
+		}
+	}
+	prfx.SRv6SID = sid
+	prfx.SRv6EndpointBehavior = info.EndpointBehavior
+	if st, ok := info.GetSIDStructure(); ok {
+		prfx.SRv6SIDStructure = st
+	}
+}
@@ -0,0 +1,73 @@
+package message
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/bgp"
+	"github.com/sbezverk/gobmp/pkg/bmp"
+	"github.com/sbezverk/gobmp/pkg/srv6"
+)
+
+func TestL3VPNSRv6SID(t *testing.T) {
+	// VPNv6 route 2001:db8:100:1::/64 RD 65000:100 with next hop 2001:db8::1, Prefix SID carries SRv6 L3 Service
+	// with SID 2001:db8:0:5:: End.DT6, 16 bits of function transposed at offset 64 into the label field 0x0123.
+	input := []byte{
+		0x00, 0x00, 0x00, 0x63,
+		0x40, 0x01, 0x01, 0x00,
+		0x40, 0x02, 0x00,
+		0xc0, 0x28, 0x25, 0x05, 0x00, 0x22, 0x00, 0x01, 0x00, 0x1e, 0x00,
+		0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x12, 0x00, 0x01, 0x00, 0x06, 0x30, 0x10, 0x10, 0x00, 0x10, 0x40,
+		0x80, 0x0e, 0x31, 0x00, 0x02, 0x80, 0x18,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00,
+		0x98, 0x01, 0x23, 0x00, 0x00, 0x00, 0xfd, 0xe8, 0x00, 0x00, 0x00, 0x64, 0x20, 0x01, 0x0d, 0xb8, 0x01, 0x00, 0x00, 0x01,
+	}
+	update, err := bgp.UnmarshalBGPUpdate(input)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp update with error: %+v", err)
+	}
+	nlri, err := bgp.UnmarshalMPReachNLRI(update.PathAttributes[3].Attribute, update.HasPrefixSID(), nil)
+	if err != nil {
+		t.Fatalf("failed to unmarshal mp reach nlri with error: %+v", err)
+	}
+	ph, err := bmp.UnmarshalPerPeerHeader(make([]byte, bmp.PerPeerHeaderLength))
+	if err != nil {
+		t.Fatalf("failed to unmarshal per peer header with error: %+v", err)
+	}
+	p := &producer{}
+	prfxs, err := p.l3vpn(nlri, 0, ph, update)
+	if err != nil {
+		t.Fatalf("failed to build l3vpn prefix messages with error: %+v", err)
+	}
+	if len(prfxs) != 1 {
+		t.Fatalf("expected 1 l3vpn prefix, got %d", len(prfxs))
+	}
+	got := prfxs[0]
+	if got.Prefix != "2001:db8:100:1::" || got.PrefixLen != 64 || got.IsIPv4 {
+		t.Fatalf("expected prefix 2001:db8:100:1::/64, got %s/%d", got.Prefix, got.PrefixLen)
+	}
+	if got.VPNRD != "65000:100" {
+		t.Fatalf("expected rd 65000:100, got %s", got.VPNRD)
+	}
+	if got.SRv6SID != "2001:db8:0:5:123::" {
+		t.Fatalf("expected srv6 sid 2001:db8:0:5:123::, got %s", got.SRv6SID)
+	}
+	if got.SRv6EndpointBehavior != 18 {
+		t.Fatalf("expected srv6 endpoint behavior 18, got %d", got.SRv6EndpointBehavior)
+	}
+	expect := &srv6.SIDStructureSubSubTLV{
+		LocalBlockLength:    48,
+		LocalNodeLength:     16,
+		FunctionLength:      16,
+		TranspositionLength: 16,
+		TranspositionOffset: 64,
+	}
+	if !reflect.DeepEqual(expect, got.SRv6SIDStructure) {
+		t.Logf("differences: %+v", deep.Equal(expect, got.SRv6SIDStructure))
+		t.Fatal("the expected sid structure does not match the actual")
+	}
+}
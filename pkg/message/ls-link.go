@@ -129,7 +129,11 @@ func (p *producer) lsLink(link *base.LinkNLRI, nextHop string, op int, ph *bmp.P
 		msg.UnidirLinkDelay = lslink.GetUnidirLinkDelay()
 		msg.UnidirLinkDelayMinMax = lslink.GetUnidirLinkDelayMinMax()
 		msg.UnidirPacketLoss = lslink.GetUnidirLinkLoss()
+		msg.UnidirPacketLossPercent = lslink.GetUnidirLinkLossPercent()
 		msg.UnidirResidualBW = lslink.GetUnidirResidualBandwidth()
+		msg.UnidirResidualBWKbps = lslink.GetUnidirResidualBandwidthKbps()
+		msg.UnidirAvailableBWKbps = lslink.GetUnidirAvailableBandwidthKbps()
+		msg.UnidirBWUtilizationKbps = lslink.GetUnidirUtilizedBandwidthKbps()
 		if adj, err := lslink.GetSRAdjacencySID(msg.ProtocolID); err == nil {
 			msg.LSAdjacencySID = adj
 		}
@@ -148,3 +152,18 @@ func (p *producer) lsLink(link *base.LinkNLRI, nextHop string, op int, ph *bmp.P
 
 	return &msg, nil
 }
+
+// GroupLSLinksByPeerSetSID correlates EPE links by their Peer Set SID, the key of the returned map
+// is the Peer Set SID value and the value is a slice of links which are members of this Peer Set.
+// Links which do not carry Peer Set SID are skipped.
+func GroupLSLinksByPeerSetSID(links []*LSLink) map[uint32][]*LSLink {
+	m := make(map[uint32][]*LSLink)
+	for _, l := range links {
+		if l == nil || l.PeerSetSID == nil {
+			continue
+		}
+		m[l.PeerSetSID.SID] = append(m[l.PeerSetSID.SID], l)
+	}
+
+	return m
+}
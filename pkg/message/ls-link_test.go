@@ -0,0 +1,39 @@
+package message
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sbezverk/gobmp/pkg/bgpls"
+	"github.com/sbezverk/gobmp/pkg/sr"
+)
+
+func TestGroupLSLinksByPeerSetSID(t *testing.T) {
+	// Peer Set SID TLV 1103 with V and L flags set and 3 bytes label 24001
+	peerSetSID := []byte{0x04, 0x4f, 0x00, 0x07, 0xc0, 0x00, 0x00, 0x00, 0x00, 0x5d, 0xc1}
+	links := make([]*LSLink, 0)
+	for _, name := range []string{"link1", "link2"} {
+		nlri, err := bgpls.UnmarshalBGPLSNLRI(peerSetSID)
+		if err != nil {
+			t.Fatalf("failed to unmarshal BGP-LS NLRI with error: %+v", err)
+		}
+		sid, err := nlri.GetPeerSetSID()
+		if err != nil {
+			t.Fatalf("failed to get Peer Set SID with error: %+v", err)
+		}
+		links = append(links, &LSLink{LinkName: name, PeerSetSID: sid})
+	}
+	// Link without Peer Set SID must not be a member of any group
+	links = append(links, &LSLink{LinkName: "link3", PeerNodeSID: &sr.PeerSID{SID: 24002}})
+	groups := GroupLSLinksByPeerSetSID(links)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 peer set but got %d", len(groups))
+	}
+	members, ok := groups[24001]
+	if !ok {
+		t.Fatalf("peer set with sid 24001 is not found")
+	}
+	if !reflect.DeepEqual(members, links[:2]) {
+		t.Fatalf("expected members %+v do not match actual %+v", links[:2], members)
+	}
+}
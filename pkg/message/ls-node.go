@@ -57,6 +57,9 @@ func (p *producer) lsNode(node *base.NodeNLRI, _ /* place holder for the next ho
 			msg.NodeFlags = f
 		}
 		msg.Name = lsnode.GetNodeName()
+		if o, err := lsnode.GetNodeOpaqueAttribute(node.ProtocolID); err == nil {
+			msg.OpaqueAttribute = o
+		}
 		msg.MTID = lsnode.GetMTID()
 		switch node.ProtocolID {
 		case base.ISISL1:
@@ -69,6 +72,12 @@ func (p *producer) lsNode(node *base.NodeNLRI, _ /* place holder for the next ho
 		} else {
 			msg.RouterID = lsnode.GetLocalIPv4RouterID()
 		}
+		if id, ok := lsnode.GetIPv4RouterID(); ok {
+			msg.IPv4RouterID = id
+		}
+		if id, ok := lsnode.GetIPv6RouterID(); ok {
+			msg.IPv6RouterID = id
+		}
 		if msd, err := lsnode.GetNodeMSD(); err == nil {
 			msg.NodeMSD = msd
 		}
@@ -77,6 +86,9 @@ func (p *producer) lsNode(node *base.NodeNLRI, _ /* place holder for the next ho
 		}
 		msg.SRAlgorithm = lsnode.GetSRAlgorithm()
 		msg.SRLocalBlock = lsnode.GetNodeSRLocalBlock()
+		if tags, err := lsnode.GetNodeAdminTags(); err == nil {
+			msg.AdminTags = tags
+		}
 		if cap, err := lsnode.GetNodeSRv6CapabilitiesTLV(); err == nil {
 			msg.SRv6CapabilitiesTLV = cap
 		}
@@ -3,6 +3,7 @@ package message
 import (
 	"fmt"
 	"net"
+	"net/netip"
 
 	"github.com/sbezverk/gobmp/pkg/base"
 	"github.com/sbezverk/gobmp/pkg/bgp"
@@ -84,13 +85,22 @@ func (p *producer) lsPrefix(prfx *base.PrefixNLRI, nextHop string, op int, ph *b
 			msg.IGPFlags = f
 		}
 		msg.IGPExtRouteTag = lsprefix.GetPrefixIGPExtRouteTag()
+		if addr, ok := lsprefix.GetOSPFForwardingAddress(); ok {
+			msg.OSPFFwdAddr = addr.String()
+		}
 		if s, err := lsprefix.GetPrefixAttrTLVs(prfx.ProtocolID); err == nil {
 			msg.PrefixAttrTLVs = s
+			msg.IsNodeSID = s.IsNodeSID()
 		}
 		if fap, err := lsprefix.GetFlexAlgoPrefixMetric(); err == nil {
 			msg.FlexAlgoPrefixMetric = fap
 		}
 		if loc, err := lsprefix.GetLSSRv6Locator(); err == nil {
+			if route != nil && !ipv4 {
+				if addr, ok := netip.AddrFromSlice(route.Prefix); ok {
+					loc.Prefix = netip.PrefixFrom(addr, int(route.Length))
+				}
+			}
 			msg.SRv6Locator = loc
 		}
 	}
@@ -2,13 +2,17 @@ package message
 
 import (
 	"encoding/json"
+	"net/netip"
 	"reflect"
 	"testing"
 
 	"github.com/go-test/deep"
 	"github.com/sbezverk/gobmp/pkg/base"
+	"github.com/sbezverk/gobmp/pkg/bgp"
 	"github.com/sbezverk/gobmp/pkg/bgpls"
+	"github.com/sbezverk/gobmp/pkg/bmp"
 	"github.com/sbezverk/gobmp/pkg/sr"
+	"github.com/sbezverk/gobmp/pkg/srv6"
 )
 
 func TestRoundTripLSPrefix(t *testing.T) {
@@ -48,3 +52,84 @@ func TestRoundTripLSPrefix(t *testing.T) {
 		t.Fatalf("TestRoundTripLSPrefix failed as original %+v does not match recovered: %+v", *original, *recovered)
 	}
 }
+
+func TestLSPrefixSRv6Locator(t *testing.T) {
+	nlri := []byte{
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x0a, 0x02, 0x03, 0x00, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x01, 0x09, 0x00, 0x07, 0x30, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01,
+	}
+	// BGP Update carrying BGP-LS attribute with SRv6 Locator TLV 1162
+	attrs := []byte{
+		0x00, 0x00, 0x00, 0x0f,
+		0x80, 0x1d, 0x0c, 0x04, 0x8a, 0x00, 0x08, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a,
+	}
+	prfx, err := base.UnmarshalPrefixNLRI(nlri, false)
+	if err != nil {
+		t.Fatalf("failed to unmarshal prefix nlri with error: %+v", err)
+	}
+	update, err := bgp.UnmarshalBGPUpdate(attrs)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp update with error: %+v", err)
+	}
+	ph, err := bmp.UnmarshalPerPeerHeader(make([]byte, bmp.PerPeerHeaderLength))
+	if err != nil {
+		t.Fatalf("failed to unmarshal per peer header with error: %+v", err)
+	}
+	p := &producer{}
+	msg, err := p.lsPrefix(prfx, "", 0, ph, update, false)
+	if err != nil {
+		t.Fatalf("failed to build ls prefix message with error: %+v", err)
+	}
+	expect := &srv6.LocatorTLV{
+		Prefix:    netip.MustParsePrefix("2001:db8:1::/48"),
+		Flag:      &srv6.LocatorFlags{},
+		Algorithm: 128,
+		Metric:    10,
+	}
+	if !reflect.DeepEqual(expect, msg.SRv6Locator) {
+		t.Logf("Differences: %+v", deep.Equal(expect, msg.SRv6Locator))
+		t.Fatal("the expected srv6 locator does not match the actual")
+	}
+}
+
+func TestLSPrefixOSPFForwardingAddress(t *testing.T) {
+	// OSPFv2 External Type 1 prefix 10.10.10.0/24
+	nlri := []byte{
+		0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x08, 0x02, 0x03, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x01,
+		0x01, 0x08, 0x00, 0x01, 0x03,
+		0x01, 0x09, 0x00, 0x04, 0x18, 0x0a, 0x0a, 0x0a,
+	}
+	// BGP Update carrying BGP-LS attribute with OSPF Forwarding Address TLV 1156
+	attrs := []byte{
+		0x00, 0x00, 0x00, 0x0b,
+		0x80, 0x1d, 0x08, 0x04, 0x84, 0x00, 0x04, 0x0a, 0x00, 0x00, 0x09,
+	}
+	prfx, err := base.UnmarshalPrefixNLRI(nlri, true)
+	if err != nil {
+		t.Fatalf("failed to unmarshal prefix nlri with error: %+v", err)
+	}
+	update, err := bgp.UnmarshalBGPUpdate(attrs)
+	if err != nil {
+		t.Fatalf("failed to unmarshal bgp update with error: %+v", err)
+	}
+	ph, err := bmp.UnmarshalPerPeerHeader(make([]byte, bmp.PerPeerHeaderLength))
+	if err != nil {
+		t.Fatalf("failed to unmarshal per peer header with error: %+v", err)
+	}
+	p := &producer{}
+	msg, err := p.lsPrefix(prfx, "", 0, ph, update, true)
+	if err != nil {
+		t.Fatalf("failed to build ls prefix message with error: %+v", err)
+	}
+	if rt := base.OSPFRouteType(msg.OSPFRouteType); !rt.IsExternal() {
+		t.Fatalf("expected external ospf route type, got %s", rt)
+	}
+	if msg.OSPFFwdAddr != "10.0.0.9" {
+		t.Fatalf("expected ospf forwarding address 10.0.0.9, got %q", msg.OSPFFwdAddr)
+	}
+	if msg.Prefix != "10.10.10.0" || msg.PrefixLen != 24 {
+		t.Fatalf("expected prefix 10.10.10.0/24, got %s/%d", msg.Prefix, msg.PrefixLen)
+	}
+}
@@ -24,7 +24,10 @@ func (p *producer) unicast(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, updat
 
 	prfxs := make([]*UnicastPrefix, 0)
 	var u *base.MPNLRI
-	if label {
+	if _, eor := update.IsEndOfRIB(); eor {
+		// End-of-RIB marker MP_UNREACH_NLRI does not carry any routes to unmarshal
+		u = &base.MPNLRI{}
+	} else if label {
 		u, err = nlri.GetNLRILU()
 		if err != nil {
 			return nil, err
@@ -93,6 +96,11 @@ func (p *producer) unicast(nlri bgp.MPNLRI, op int, ph *bmp.PerPeerHeader, updat
 			copy(a, e.Prefix)
 			prfx.Prefix = net.IP(a).To4().String()
 		}
+		if prfx.Nexthop != "" {
+			// Next hop family is defined by its length and not by NLRI AFI, IPv4 NLRI can be advertised
+			// with IPv6 next hop, RFC 8950
+			prfx.IsNexthopIPv4 = !nlri.IsNextHopIPv6()
+		}
 		if label {
 			for _, l := range e.Label {
 				prfx.Labels = append(prfx.Labels, l.Value)
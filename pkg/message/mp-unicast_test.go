@@ -0,0 +1,92 @@
+package message
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sbezverk/gobmp/pkg/bgp"
+	"github.com/sbezverk/gobmp/pkg/bmp"
+)
+
+func TestUnicastNextHop(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		label  bool
+		expect []*UnicastPrefix
+	}{
+		{
+			name: "labeled ipv4 prefix with ipv6 next hop",
+			// MP_REACH_NLRI AFI 1 SAFI 4 next hop 2001:db8::1, 10.10.10.0/24 label 100
+			input: []byte{
+				0x00, 0x00, 0x00, 0x1f,
+				0x80, 0x0e, 0x1c, 0x00, 0x01, 0x04, 0x10,
+				0x20, 0x01, 0x0d, 0xb8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+				0x00, 0x30, 0x00, 0x06, 0x41, 0x0a, 0x0a, 0x0a,
+			},
+			label: true,
+			expect: []*UnicastPrefix{
+				{
+					Action:        "add",
+					PrefixLen:     24,
+					Prefix:        "10.10.10.0",
+					IsIPv4:        true,
+					Nexthop:       "2001:db8::1",
+					IsNexthopIPv4: false,
+					Labels:        []uint32{100},
+				},
+			},
+		},
+		{
+			name: "labeled ipv4 prefix with ipv4 next hop",
+			// MP_REACH_NLRI AFI 1 SAFI 4 next hop 10.0.0.1, 10.10.10.0/24 label 100
+			input: []byte{
+				0x00, 0x00, 0x00, 0x13,
+				0x80, 0x0e, 0x10, 0x00, 0x01, 0x04, 0x04, 0x0a, 0x00, 0x00, 0x01,
+				0x00, 0x30, 0x00, 0x06, 0x41, 0x0a, 0x0a, 0x0a,
+			},
+			label: true,
+			expect: []*UnicastPrefix{
+				{
+					Action:        "add",
+					PrefixLen:     24,
+					Prefix:        "10.10.10.0",
+					IsIPv4:        true,
+					Nexthop:       "10.0.0.1",
+					IsNexthopIPv4: true,
+					Labels:        []uint32{100},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := bgp.UnmarshalBGPUpdate(tt.input)
+			if err != nil {
+				t.Fatalf("failed to unmarshal bgp update with error: %+v", err)
+			}
+			nlri, err := bgp.UnmarshalMPReachNLRI(update.PathAttributes[0].Attribute, false, nil)
+			if err != nil {
+				t.Fatalf("failed to unmarshal mp reach nlri with error: %+v", err)
+			}
+			ph, err := bmp.UnmarshalPerPeerHeader(make([]byte, bmp.PerPeerHeaderLength))
+			if err != nil {
+				t.Fatalf("failed to unmarshal per peer header with error: %+v", err)
+			}
+			p := &producer{}
+			got, err := p.unicast(nlri, 0, ph, update, tt.label)
+			if err != nil {
+				t.Fatalf("failed to build unicast prefix messages with error: %+v", err)
+			}
+			for _, u := range got {
+				// Per peer header and attributes derived fields are not under test
+				u.PeerHash, u.PeerIP, u.Timestamp, u.BaseAttributes = "", "", "", nil
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected unicast prefixes do not match the actual")
+			}
+		})
+	}
+}
@@ -79,6 +79,9 @@ func (p *producer) producePeerMessage(op int, msg bmp.Message) {
 		}
 		m.AdvCapabilities = peerUpMsg.SentOpen.GetCapabilities()
 		m.RcvCapabilities = peerUpMsg.ReceivedOpen.GetCapabilities()
+		if host, _, ok := peerUpMsg.ReceivedOpen.GetHostname(); ok {
+			m.Name = host
+		}
 		if glog.V(6) {
 			glog.Infof("producer for speaker ip: %s add path: %+v", p.speakerIP, p.addPathCapable)
 		}
@@ -103,6 +106,9 @@ func (p *producer) producePeerMessage(op int, msg bmp.Message) {
 		m.IsIPv4 = !msg.PeerHeader.IsRemotePeerIPv6()
 		m.InfoData = make([]byte, len(peerDownMsg.Data))
 		copy(m.InfoData, peerDownMsg.Data)
+		if n, ok := peerDownMsg.GetTableName(); ok {
+			m.TableName = n
+		}
 
 	}
 	if err := p.marshalAndPublish(&m, bmp.PeerStateChangeMsg, []byte(m.RouterHash), false); err != nil {
@@ -1,6 +1,7 @@
 package message
 
 import (
+	"net"
 	"reflect"
 	"strconv"
 
@@ -196,6 +197,8 @@ type LSNode struct {
 	Timestamp           string                          `json:"timestamp,omitempty"`
 	IGPRouterID         string                          `json:"igp_router_id,omitempty"`
 	RouterID            string                          `json:"router_id,omitempty"`
+	IPv4RouterID        net.IP                          `json:"ipv4_router_id,omitempty"`
+	IPv6RouterID        net.IP                          `json:"ipv6_router_id,omitempty"`
 	ASN                 uint32                          `json:"asn,omitempty"`
 	LSID                uint32                          `json:"ls_id,omitempty"`
 	MTID                []*base.MultiTopologyIdentifier `json:"mt_id_tlv,omitempty"`
@@ -204,12 +207,14 @@ type LSNode struct {
 	ProtocolID          base.ProtoID                    `json:"protocol_id,omitempty"`
 	NodeFlags           *bgpls.NodeAttrFlags            `json:"node_flags,omitempty"`
 	Name                string                          `json:"name,omitempty"`
+	OpaqueAttribute     *bgpls.NodeOpaqueAttribute      `json:"opaque_attribute,omitempty"`
 	SRCapabilities      *sr.Capability                  `json:"ls_sr_capabilities,omitempty"`
 	SRAlgorithm         []int                           `json:"sr_algorithm,omitempty"`
 	SRLocalBlock        *sr.LocalBlock                  `json:"sr_local_block,omitempty"`
 	SRv6CapabilitiesTLV *srv6.CapabilityTLV             `json:"srv6_capabilities_tlv,omitempty"`
 	NodeMSD             []*base.MSDTV                   `json:"node_msd,omitempty"`
 	FlexAlgoDefinition  []*bgpls.FlexAlgoDefinition     `json:"flex_algo_definition,omitempty"`
+	AdminTags           []uint32                        `json:"admin_tags,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
 	IsAdjRIBInPost   bool `json:"is_adj_rib_in_post_policy"`
 	IsAdjRIBOutPost  bool `json:"is_adj_rib_out_post_policy"`
@@ -281,6 +286,11 @@ type LSLink struct {
 	UnidirResidualBW      uint32                        `json:"unidir_residual_bw,omitempty"`
 	UnidirAvailableBW     uint32                        `json:"unidir_available_bw,omitempty"`
 	UnidirBWUtilization   uint32                        `json:"unidir_bw_utilization,omitempty"`
+	// Packet loss is in percent of packets and bandwidth is in kbps, RFC 8571
+	UnidirPacketLossPercent float64 `json:"unidir_packet_loss_percent,omitempty"`
+	UnidirResidualBWKbps    uint64  `json:"unidir_residual_bw_kbps,omitempty"`
+	UnidirAvailableBWKbps   uint64  `json:"unidir_available_bw_kbps,omitempty"`
+	UnidirBWUtilizationKbps uint64  `json:"unidir_bw_utilization_kbps,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
 	IsAdjRIBInPost   bool `json:"is_adj_rib_in_post_policy"`
 	IsAdjRIBOutPost  bool `json:"is_adj_rib_out_post_policy"`
@@ -315,6 +325,13 @@ type L3VPNPrefix struct {
 	VPNRD          string              `json:"vpn_rd,omitempty"`
 	VPNRDType      uint16              `json:"vpn_rd_type"`
 	PrefixSID      *prefixsid.PSid     `json:"prefix_sid,omitempty"`
+	// SRv6SID carries SRv6 L3 Service SID with transposed bits restored from the label,
+	// SRv6EndpointBehavior and SRv6SIDStructure are set only with SRv6SID.
+	SRv6SID              string                      `json:"srv6_sid,omitempty"`
+	SRv6EndpointBehavior uint16                      `json:"srv6_endpoint_behavior,omitempty"`
+	SRv6SIDStructure     *srv6.SIDStructureSubSubTLV `json:"srv6_sid_structure,omitempty"`
+	// SiteOfOrigin carries values of Route Origin extended communities used to prevent loops at multihomed sites
+	SiteOfOrigin []string `json:"site_of_origin,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
 	IsAdjRIBInPost   bool `json:"is_adj_rib_in_post_policy"`
 	IsAdjRIBOutPost  bool `json:"is_adj_rib_out_post_policy"`
@@ -355,6 +372,7 @@ type LSPrefix struct {
 	PrefixLen            int32                         `json:"prefix_len,omitempty"`
 	PrefixMetric         uint32                        `json:"prefix_metric,omitempty"`
 	PrefixAttrTLVs       *bgpls.PrefixAttrTLVs         `json:"prefix_attr_tlvs,omitempty"`
+	IsNodeSID            bool                          `json:"is_node_sid,omitempty"`
 	FlexAlgoPrefixMetric []*bgpls.FlexAlgoPrefixMetric `json:"flex_algo_prefix_metric,omitempty"`
 	SRv6Locator          *srv6.LocatorTLV              `json:"srv6_locator,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
@@ -441,9 +459,18 @@ type EVPNPrefix struct {
 	MAC            string              `json:"mac,omitempty"`
 	MACLength      uint8               `json:"mac_len,omitempty"`
 	RouteType      uint8               `json:"route_type,omitempty"`
-	// TODO Type 3 carries nlri 22
-	// https://tools.ietf.org/html/rfc6514
-	// Add to the message
+	// DefaultGateway is set for Type 2 routes carrying Default Gateway Extended Community, https://tools.ietf.org/html/rfc7432#section-7.8
+	DefaultGateway bool `json:"default_gateway,omitempty"`
+	// PMSITunnel is carried by Type 3 routes, https://tools.ietf.org/html/rfc6514
+	PMSITunnel *bgp.PMSITunnel `json:"pmsi_tunnel,omitempty"`
+	// L3VNI and RouterMAC are set for Type 2 and Type 5 routes of symmetric IRB, https://tools.ietf.org/html/rfc9135
+	L3VNI     uint32 `json:"l3vni,omitempty"`
+	RouterMAC string `json:"router_mac,omitempty"`
+	// ConsistentHashSortOrder is set for routes carrying Consistent Hash Sort Order Extended Community used
+	// by all-active multihomed sites, draft-ietf-bess-service-chaining
+	ConsistentHashSortOrder uint32 `json:"consistent_hash_sort_order,omitempty"`
+	// L2Attributes is carried by Type 1 per EVI routes of EVPN VPWS, https://tools.ietf.org/html/rfc8214
+	L2Attributes *bgp.EVPNL2Attributes `json:"l2_attributes,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
 	IsAdjRIBInPost   bool `json:"is_adj_rib_in_post_policy"`
 	IsAdjRIBOutPost  bool `json:"is_adj_rib_out_post_policy"`
@@ -509,6 +536,17 @@ type Flowspec struct {
 	PathID         int32               `json:"path_id,omitempty"`
 	SpecHash       string              `json:"spec_hash,omitempty"`
 	Spec           []flowspec.Spec     `json:"spec,omitempty"`
+	// VPNRD and VPNRDType carry Route Distinguisher of VPN Flowspec rule, SAFI 134
+	VPNRD     string `json:"vpn_rd,omitempty"`
+	VPNRDType uint16 `json:"vpn_rd_type,omitempty"`
+	// RateBytes and RatePPS carry the rate of traffic-rate-bytes and traffic-rate-packets actions,
+	// nil when the action is not present, 0 means discard all traffic.
+	RateBytes *float32 `json:"rate_bytes,omitempty"`
+	RatePPS   *float32 `json:"rate_pps,omitempty"`
+	// RemarkDSCP carries DSCP value of traffic-marking action, nil when the action is not present
+	RemarkDSCP *uint8 `json:"remark_dscp,omitempty"`
+	// RedirectIPv6RT carries IPv6 Address Specific route target of redirect to VRF action, RFC 8956
+	RedirectIPv6RT string `json:"redirect_ipv6_rt,omitempty"`
 	// Values are assigned based on PerPeerHeader flas
 	IsAdjRIBInPost   bool `json:"is_adj_rib_in_post_policy"`
 	IsAdjRIBOutPost  bool `json:"is_adj_rib_out_post_policy"`
@@ -539,4 +577,24 @@ type Stats struct {
 	LocalRib                   uint64 `json:"local_rib,omitempty"`
 	UpdatesAsWithdraw          uint32 `json:"updates_as_withdraw,omitempty"`
 	PrefixesAsWithdraw         uint32 `json:"prefixes_as_withdraw,omitempty"`
+	DuplicateUpdates           uint32 `json:"duplicate_updates,omitempty"`
+	// PerAFISAFIAdjRIBsIn and PerAFISAFILocalRib carry per AFI/SAFI number of routes in Adj-RIBs-In
+	// and in Loc-RIB, stat types 9 and 10, the latter reported by Loc-RIB peers as well, RFC 9069.
+	PerAFISAFIAdjRIBsIn []AFISAFIStat `json:"per_afi_safi_adj_rib_in,omitempty"`
+	PerAFISAFILocalRib  []AFISAFIStat `json:"per_afi_safi_local_rib,omitempty"`
+	// Unknown carries stats of types which are not decoded, or which value does not match the expected length
+	Unknown []RawStat `json:"unknown,omitempty"`
+}
+
+// AFISAFIStat defines a BMP Stats Report per AFI/SAFI gauge
+type AFISAFIStat struct {
+	AFI   uint16 `json:"afi"`
+	SAFI  uint8  `json:"safi"`
+	Value uint64 `json:"value"`
+}
+
+// RawStat defines a BMP Stats Report stat which is not decoded
+type RawStat struct {
+	Type  int16  `json:"type"`
+	Value []byte `json:"value,omitempty"`
 }
@@ -1,7 +1,6 @@
 package sr
 
 import (
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -23,6 +22,19 @@ type AdjacencySIDTLV struct {
 	SID    uint32            `json:"sid,omitempty"`
 }
 
+// IsBackup returns true when the Adjacency SID has B flag set, meaning the SID is eligible for
+// protection (e.g. TI-LFA backup path), for protocols other than ISIS and OSPF false is returned.
+func (a *AdjacencySIDTLV) IsBackup() bool {
+	switch f := a.Flags.(type) {
+	case *AdjISISFlags:
+		return f.BFlag
+	case *AdjOSPFFlags:
+		return f.BFlag
+	}
+
+	return false
+}
+
 func (a *AdjacencySIDTLV) MarshalJSON() ([]byte, error) {
 	switch a.Flags.(type) {
 	case *AdjISISFlags:
@@ -149,16 +161,14 @@ func UnmarshalAdjacencySIDTLV(b []byte, proto base.ProtoID) (*AdjacencySIDTLV, e
 	// SID length would be Length of b - Flags 1 byte - Algorithm 1 byte - 2 bytes Reserved
 	// If length of Adjacency SID TLV 7 bytes, then SID is 20 bits label, if 8 bytes then SID is 4 bytes index
 	p += 2
-	s := make([]byte, 4)
-	switch len(b) {
-	case 7:
-		copy(s[1:], b[p:p+3])
-	case 8:
-		copy(s, b[p:p+4])
-	default:
+	if len(b) != 7 && len(b) != 8 {
 		return nil, fmt.Errorf("invalid length %d for Adjacency SID TLV", len(b))
 	}
-	asid.SID = binary.BigEndian.Uint32(s)
+	s, err := decodeSIDLabel(len(b)-p, b[p:])
+	if err != nil {
+		return nil, err
+	}
+	asid.SID = s.Value
 
 	return &asid, nil
 }
@@ -39,16 +39,11 @@ func UnmarshalSRCapabilitySubTLV(b []byte) ([]CapabilitySubTLV, error) {
 		default:
 			return nil, fmt.Errorf("unknown SR Capability tlv type %d", t)
 		}
-		s := make([]byte, 4)
-		switch l {
-		case 3:
-			copy(s[1:], b[p:p+int(l)])
-		case 4:
-			copy(s, b[p:p+int(l)])
-		default:
-			return nil, fmt.Errorf("invalid length %d for Prefix SID TLV", len(b))
+		s, err := decodeSIDLabel(int(l), b[p:])
+		if err != nil {
+			return nil, err
 		}
-		cap.SID = binary.BigEndian.Uint32(s)
+		cap.SID = s.Value
 		p += int(l)
 		caps = append(caps, cap)
 	}
@@ -34,26 +34,19 @@ func UnmarshalSRLocalBlockTLV(b []byte) ([]LocalBlockTLV, error) {
 		p += 2
 		l := binary.BigEndian.Uint16(b[p : p+2])
 		p += 2
-		v := make([]byte, 4)
-		if l == 3 {
-			copy(v[1:], b[p:p+int(l)])
-		} else {
-			copy(v, b[p:p+int(l)])
-		}
-		s := binary.BigEndian.Uint32(v)
-		p += int(l)
 		switch t {
 		case 1161:
 			// SID Subtlv
-			switch l {
-			case 3:
-				// Length 3 indicates a label, only 20 rightmost bits are used
-				s &= 0x000fffff
-				tlv.Label = &s
-			case 4:
-				// Length 4 indicates an index
-				tlv.Index = &s
+			s, err := decodeSIDLabel(int(l), b[p:])
+			if err != nil {
+				return nil, err
+			}
+			if s.IsLabel() {
+				tlv.Label = &s.Value
+			} else {
+				tlv.Index = &s.Value
 			}
+			p += int(l)
 		default:
 			return nil, fmt.Errorf("unknown SR LocalBLock tlv %d", t)
 		}
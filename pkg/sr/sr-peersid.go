@@ -1,7 +1,6 @@
 package sr
 
 import (
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -63,26 +62,19 @@ func UnmarshalPeerSID(b []byte) (*PeerSID, error) {
 	// SID length would be Length of b - Flags 1 byte - Weight 1 byte - 2 bytes Reserved
 	p += 2
 	l := len(b) - 4
-	s := make([]byte, 4)
-	switch l {
-	case 3:
-		if !psid.Flags.VFlag || !psid.Flags.LFlag {
-			// When sid is 3 bytes, V and L flags MUST be set to "true", if not, error out
-			return nil, fmt.Errorf("sid length is 3 bytes but V flag is NOT set to \"true\"")
-		}
-		copy(s[1:], b[p:p+3])
-		// Since label uses only 20 bits for label, clear first 4 bits of s[1]
-		s[1] &= 0x0f
-	case 4:
-		if psid.Flags.VFlag {
-			// When sid is 4 bytes, V flag must NOT be set to "true", if not, error out
-			return nil, fmt.Errorf("sid length is 4 bytes but V flag is set to \"true\"")
-		}
-		copy(s, b[p:p+4])
-	default:
+	s, err := decodeSIDLabel(l, b[p:])
+	if err != nil {
 		return nil, fmt.Errorf("software bug in peer sid processing logic, byte slice: %s", tools.MessageHex(b))
 	}
-	psid.SID = binary.BigEndian.Uint32(s)
+	if s.IsLabel() && (!psid.Flags.VFlag || !psid.Flags.LFlag) {
+		// When sid is 3 bytes, V and L flags MUST be set to "true", if not, error out
+		return nil, fmt.Errorf("sid length is 3 bytes but V flag is NOT set to \"true\"")
+	}
+	if s.IsIndex() && psid.Flags.VFlag {
+		// When sid is 4 bytes, V flag must NOT be set to "true", if not, error out
+		return nil, fmt.Errorf("sid length is 4 bytes but V flag is set to \"true\"")
+	}
+	psid.SID = s.Value
 
 	return &psid, nil
 }
@@ -1,7 +1,6 @@
 package sr
 
 import (
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
 
@@ -23,6 +22,14 @@ type PrefixSIDTLV struct {
 	SID       uint32         `json:"prefix_sid"`
 }
 
+// IsNodeSID returns true when Prefix SID carries IS-IS N flag, meaning the SID identifies the node
+// advertising the prefix, RFC 8667 section 2.1.1. OSPF Prefix SID flags do not have N flag, for OSPF
+// Node SID is signalled by N flag of Prefix Attribute Flags, RFC 7684 section 2.1.
+func (p *PrefixSIDTLV) IsNodeSID() bool {
+	f, ok := p.Flags.(*ISISFlags)
+	return ok && f.NFlag
+}
+
 func (p *PrefixSIDTLV) MarshalJSON() ([]byte, error) {
 	switch p.Flags.(type) {
 	case *ISISFlags:
@@ -117,6 +124,9 @@ func UnmarshalPrefixSIDTLV(b []byte, proto base.ProtoID) (*PrefixSIDTLV, error)
 	if glog.V(6) {
 		glog.Infof("Prefix SID TLV Raw: %s for proto: %+v", tools.MessageHex(b), proto)
 	}
+	if len(b) != 7 && len(b) != 8 {
+		return nil, fmt.Errorf("invalid length %d for Prefix SID TLV", len(b))
+	}
 	psid := PrefixSIDTLV{}
 	p := 0
 	switch proto {
@@ -149,16 +159,11 @@ func UnmarshalPrefixSIDTLV(b []byte, proto base.ProtoID) (*PrefixSIDTLV, error)
 	// SID length would be Length of b - Flags 1 byte - Algorithm 1 byte - 2 bytes Reserved
 	// If length of Prefix SID TLV 7 bytes, then SID is 20 bits label, if 8 bytes then SID is 4 bytes index
 	p += 2
-	s := make([]byte, 4)
-	switch len(b) {
-	case 7:
-		copy(s[1:], b[p:p+3])
-	case 8:
-		copy(s, b[p:p+4])
-	default:
-		return nil, fmt.Errorf("invalid length %d for Prefix SID TLV", len(b))
+	s, err := decodeSIDLabel(len(b)-p, b[p:])
+	if err != nil {
+		return nil, err
 	}
-	psid.SID = binary.BigEndian.Uint32(s)
+	psid.SID = s.Value
 
 	return &psid, nil
 }
@@ -0,0 +1,42 @@
+package sr
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SIDLabel defines a value of SID/Label sub-TLV (1161) or of SID/Label field of SR TLVs,
+// the value is either a 20 bits MPLS label or a 4 bytes SID index, distinguished by the length.
+// https://www.rfc-editor.org/rfc/rfc9085#section-2.1.1
+type SIDLabel struct {
+	Value uint32
+	label bool
+}
+
+// IsLabel returns true when SID/Label carries a 20 bits MPLS label
+func (s SIDLabel) IsLabel() bool {
+	return s.label
+}
+
+// IsIndex returns true when SID/Label carries a 4 bytes SID index
+func (s SIDLabel) IsIndex() bool {
+	return !s.label
+}
+
+// decodeSIDLabel decodes SID/Label value of length l from b, length 3 indicates a label
+// of which only 20 rightmost bits are used, length 4 indicates an index.
+func decodeSIDLabel(l int, b []byte) (SIDLabel, error) {
+	if len(b) < l {
+		return SIDLabel{}, fmt.Errorf("not enough bytes to decode sid/label of length %d", l)
+	}
+	switch l {
+	case 3:
+		s := make([]byte, 4)
+		copy(s[1:], b[:3])
+		return SIDLabel{Value: binary.BigEndian.Uint32(s) & 0x000fffff, label: true}, nil
+	case 4:
+		return SIDLabel{Value: binary.BigEndian.Uint32(b[:4])}, nil
+	}
+
+	return SIDLabel{}, fmt.Errorf("invalid sid/label length %d", l)
+}
@@ -82,3 +82,67 @@ func TestUnmarshalSRLocalBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeSIDLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		l       int
+		raw     []byte
+		expect  SIDLabel
+		isLabel bool
+		fail    bool
+	}{
+		{
+			name:    "3 bytes label",
+			l:       3,
+			raw:     []byte{0x00, 0x3a, 0x98},
+			expect:  SIDLabel{Value: 15000, label: true},
+			isLabel: true,
+		},
+		{
+			name:    "3 bytes label with high bits set",
+			l:       3,
+			raw:     []byte{0xf0, 0x3a, 0x98},
+			expect:  SIDLabel{Value: 15000, label: true},
+			isLabel: true,
+		},
+		{
+			name:   "4 bytes index",
+			l:      4,
+			raw:    []byte{0x00, 0x01, 0x86, 0xa0},
+			expect: SIDLabel{Value: 100000},
+		},
+		{
+			name: "invalid length",
+			l:    2,
+			raw:  []byte{0x00, 0x01},
+			fail: true,
+		},
+		{
+			name: "not enough bytes",
+			l:    4,
+			raw:  []byte{0x00, 0x01, 0x86},
+			fail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeSIDLabel(tt.l, tt.raw)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("expected to succeed but failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("expected to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Fatalf("expected sid/label %+v does not match actual %+v", tt.expect, got)
+			}
+			if got.IsLabel() != tt.isLabel || got.IsIndex() == tt.isLabel {
+				t.Fatalf("expected label %t, got label %t index %t", tt.isLabel, got.IsLabel(), got.IsIndex())
+			}
+		})
+	}
+}
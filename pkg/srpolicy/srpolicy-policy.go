@@ -0,0 +1,59 @@
+package srpolicy
+
+import (
+	"fmt"
+	"net"
+)
+
+// DefaultPreference defines the preference of SR Policy candidate path advertised without Preference Sub TLV
+// https://www.rfc-editor.org/rfc/rfc9256#section-2.7
+const DefaultPreference = 100
+
+// Policy defines SR Policy candidate path assembled from SR Policy NLRI (SAFI 73), which identifies the policy
+// by Color and Endpoint and the candidate path by Distinguisher, and SR Policy TLV carried in Tunnel Encapsulation
+// attribute, which carries the candidate path's attributes.
+type Policy struct {
+	Distinguisher uint32         `json:"distinguisher"`
+	Color         uint32         `json:"color"`
+	Endpoint      net.IP         `json:"endpoint,omitempty"`
+	Preference    uint32         `json:"preference"`
+	Priority      byte           `json:"priority,omitempty"`
+	Name          string         `json:"policy_name,omitempty"`
+	PathName      string         `json:"path_name,omitempty"`
+	BindingSID    *BindingSID    `json:"binding_sid,omitempty"`
+	ENLP          *ENLP          `json:"enlp,omitempty"`
+	SegmentList   []*SegmentList `json:"segment_list,omitempty"`
+	// Withdrawn is set when the candidate path is withdrawn, only the fields of NLRI are set
+	Withdrawn bool `json:"withdrawn,omitempty"`
+}
+
+// NewPolicy builds SR Policy candidate path from SR Policy NLRI and SR Policy TLV, tlv is nil for withdrawn
+// candidate paths or candidate paths advertised without Tunnel Encapsulation attribute.
+func NewPolicy(nlri *NLRI73, tlv *TLV) (*Policy, error) {
+	if nlri == nil {
+		return nil, fmt.Errorf("sr policy nlri is nil")
+	}
+	p := &Policy{
+		Distinguisher: nlri.Distinguisher,
+		Color:         nlri.Color,
+		Endpoint:      net.IP(nlri.Endpoint),
+		Preference:    DefaultPreference,
+		SegmentList:   make([]*SegmentList, 0),
+	}
+	if tlv == nil {
+		return p, nil
+	}
+	if tlv.Preference != nil {
+		p.Preference = tlv.Preference.Preference
+	}
+	p.Priority = tlv.Priority
+	p.Name = tlv.Name
+	p.PathName = tlv.PathName
+	p.BindingSID = tlv.BindingSID
+	p.ENLP = tlv.ENLP
+	if tlv.SegmentList != nil {
+		p.SegmentList = tlv.SegmentList
+	}
+
+	return p, nil
+}
@@ -67,6 +67,16 @@ type SegmentList struct {
 	Segment []Segment `json:"segments,omitempty"`
 }
 
+// GetWeight returns the weight of the segment list used for weighted ECMP across segment lists
+// of the candidate path, when Weight Sub TLV is not present, the default weight of 1 is returned.
+func (sl *SegmentList) GetWeight() uint32 {
+	if sl.Weight == nil {
+		return 1
+	}
+
+	return sl.Weight.Weight
+}
+
 // UnmarshalJSON is custom Unmarshal fuction which will populate Slice of Segment interfaces with correct,
 // depending on the segment type value
 func (sl *SegmentList) UnmarshalJSON(b []byte) error {
@@ -142,13 +152,19 @@ func UnmarshalSegmentListSTLV(b []byte) (*SegmentList, error) {
 	for p < len(b) {
 		t := int(b[p])
 		p++
+		if p >= len(b) {
+			return nil, fmt.Errorf("not enough bytes to unmarshal segment list sub tlv %d", t)
+		}
+		l := int(b[p])
+		p++
+		if p+l > len(b) {
+			return nil, fmt.Errorf("invalid length %d of segment list sub tlv %d", l, t)
+		}
 		switch t {
 		case WEIGHTSTLV:
 			if sl.Weight != nil {
 				return nil, fmt.Errorf("Segment List Sub TLV can carry a single instance of Weight")
 			}
-			l := b[p]
-			p++
 			if l != 6 {
 				return nil, fmt.Errorf("invalid length %d of raw data for Weight Sub TLV", l)
 			}
@@ -157,20 +173,16 @@ func UnmarshalSegmentListSTLV(b []byte) (*SegmentList, error) {
 				Weight: binary.BigEndian.Uint32(b[p+2 : p+2+4]),
 			}
 			sl.Weight = w
-			p += int(l)
 		case int(TypeA):
 			glog.Infof("Segment of type A")
-			l := b[p]
-			p++
 			if l != 6 {
 				return nil, fmt.Errorf("invalid length %d of raw data for Type A Segment Sub TLV", l)
 			}
-			s, err := UnmarshalTypeASegment(b[p : p+int(l)])
+			s, err := UnmarshalTypeASegment(b[p : p+l])
 			if err != nil {
 				return nil, err
 			}
 			sl.Segment = append(sl.Segment, s)
-			p += int(l)
 		case int(TypeB):
 			glog.Infof("Segment of type B not implemented")
 		case int(TypeC):
@@ -194,6 +206,7 @@ func UnmarshalSegmentListSTLV(b []byte) (*SegmentList, error) {
 		default:
 			return nil, fmt.Errorf("unknown type of segment sub tlv %d", t)
 		}
+		p += l
 	}
 	return sl, nil
 }
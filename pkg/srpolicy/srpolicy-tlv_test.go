@@ -2,6 +2,7 @@ package srpolicy
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"flag"
 	"testing"
 
@@ -137,3 +138,56 @@ func TestUnmarshalSRPolicyTLV(t *testing.T) {
 		})
 	}
 }
+
+func TestSegmentListWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect []uint32
+	}{
+		{
+			name:   "two weighted segment lists",
+			input:  []byte{0x00, 0x0F, 0x00, 0x48, 0x0C, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x44, 0x0D, 0x06, 0x00, 0x00, 0xDB, 0xBA, 0x00, 0x00, 0x80, 0x00, 0x19, 0x00, 0x09, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00, 0x01, 0x06, 0x00, 0x00, 0x05, 0xDC, 0x10, 0x00, 0x80, 0x00, 0x19, 0x00, 0x09, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00, 0x01, 0x06, 0x00, 0x00, 0x05, 0xDC, 0xD0, 0x00},
+			expect: []uint32{1, 3},
+		},
+		{
+			name:   "segment list without weight",
+			input:  []byte{0x00, 0x0F, 0x00, 0x0C, 0x80, 0x00, 0x09, 0x00, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00},
+			expect: []uint32{1},
+		},
+		{
+			name:   "segment list with not implemented segment type",
+			input:  []byte{0x00, 0x0F, 0x00, 0x28, 0x80, 0x00, 0x25, 0x00, 0x09, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x05, 0x0D, 0x12, 0x00, 0x00, 0x20, 0x01, 0x0D, 0xB8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x01, 0x06, 0x00, 0x00, 0x18, 0x6A, 0xA0, 0x00},
+			expect: []uint32{5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tlv, err := UnmarshalSRPolicyTLV(tt.input)
+			if err != nil {
+				t.Fatalf("Supposed to succeed but failed with error: %+v", err)
+			}
+			if len(tlv.SegmentList) != len(tt.expect) {
+				t.Fatalf("expected %d segment lists, got %d", len(tt.expect), len(tlv.SegmentList))
+			}
+			for i, sl := range tlv.SegmentList {
+				if got := sl.GetWeight(); got != tt.expect[i] {
+					t.Errorf("segment list %d expected weight %d, got %d", i, tt.expect[i], got)
+				}
+			}
+			b, err := json.Marshal(tlv.SegmentList)
+			if err != nil {
+				t.Fatalf("failed to marshal segment lists with error: %+v", err)
+			}
+			var sls []*SegmentList
+			if err := json.Unmarshal(b, &sls); err != nil {
+				t.Fatalf("failed to unmarshal segment lists with error: %+v", err)
+			}
+			for i, sl := range sls {
+				if got := sl.GetWeight(); got != tt.expect[i] {
+					t.Errorf("unmarshaled segment list %d expected weight %d, got %d", i, tt.expect[i], got)
+				}
+			}
+		})
+	}
+}
@@ -115,6 +115,50 @@ func UnmarshalInformationSubTLV(b []byte) (*InformationSubTLV, error) {
 	return tlv, nil
 }
 
+// GetSIDStructure returns SID Structure Sub Sub TLV of the Information Sub TLV and true,
+// if the Sub Sub TLV is not present, false is returned.
+func (istlv *InformationSubTLV) GetSIDStructure() (*SIDStructureSubSubTLV, bool) {
+	for _, sstlv := range istlv.SubSubTLVs[1] {
+		if s, ok := sstlv.(*SIDStructureSubSubTLV); ok {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
+
+// TransposeSID returns SRv6 SID with transposed bits restored from label, label is 24 bits value of
+// NLRI's label field, TranspositionLength high order bits of which are placed into SID starting at
+// TranspositionOffset bit. If SID Structure is not present or transposition is not used, SID is returned
+// as is, https://www.rfc-editor.org/rfc/rfc9252#section-4
+func (istlv *InformationSubTLV) TransposeSID(label uint32) (string, error) {
+	st, ok := istlv.GetSIDStructure()
+	if !ok || st.TranspositionLength == 0 {
+		return istlv.SID, nil
+	}
+	tl := int(st.TranspositionLength)
+	to := int(st.TranspositionOffset)
+	if tl > 24 || to+tl > 128 {
+		return "", fmt.Errorf("invalid transposition length %d offset %d", tl, to)
+	}
+	sid := net.ParseIP(istlv.SID).To16()
+	if sid == nil {
+		return "", fmt.Errorf("invalid srv6 sid %s", istlv.SID)
+	}
+	bits := label >> (24 - tl)
+	for i := 0; i < tl; i++ {
+		n := to + i
+		mask := byte(0x80 >> (n % 8))
+		if bits&(1<<(tl-1-i)) != 0 {
+			sid[n/8] |= mask
+		} else {
+			sid[n/8] &^= mask
+		}
+	}
+
+	return sid.String(), nil
+}
+
 // SubTLV defines SRv6 Service's Sub TLV object
 type SvcSubTLV interface{}
 
@@ -127,6 +171,18 @@ type L3Service struct {
 	SubTLVs map[uint8][]SvcSubTLV `json:"sub_tlvs,omitempty"`
 }
 
+// GetInformationSubTLV returns the first SRv6 SID Information Sub TLV of the service and true,
+// if the service does not carry Information Sub TLV, false is returned.
+func (l3s *L3Service) GetInformationSubTLV() (*InformationSubTLV, bool) {
+	for _, stlv := range l3s.SubTLVs[1] {
+		if i, ok := stlv.(*InformationSubTLV); ok {
+			return i, true
+		}
+	}
+
+	return nil, false
+}
+
 // UnmarshalJSON unmarshals a slice of byte into L3Service object
 func (l3s *L3Service) UnmarshalJSON(b []byte) error {
 	l3s.SubTLVs = make(map[uint8][]SvcSubTLV)
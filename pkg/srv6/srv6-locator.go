@@ -3,6 +3,7 @@ package srv6
 import (
 	"encoding/binary"
 	"fmt"
+	"net/netip"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/gobmp/pkg/base"
@@ -26,8 +27,10 @@ func UnmarshalLocatorFlags(b []byte) (*LocatorFlags, error) {
 }
 
 // LocatorTLV defines SRv6 Locator TLV object
-// No RFC yet
+// https://tools.ietf.org/html/rfc9514#section-5.1
 type LocatorTLV struct {
+	// Prefix is the locator, it is not carried by the TLV but by the Prefix NLRI the TLV is advertised with.
+	Prefix    netip.Prefix   `json:"prefix"`
 	Flag      *LocatorFlags  `json:"flags,omitempty"`
 	Algorithm uint8          `json:"algo"`
 	Metric    uint32         `json:"metric"`
@@ -39,6 +42,10 @@ func UnmarshalSRv6LocatorTLV(b []byte) (*LocatorTLV, error) {
 	if glog.V(6) {
 		glog.Infof("SRv6 Locator TLV Raw: %s", tools.MessageHex(b))
 	}
+	// Flags, Algorithm, 2 reserved bytes and Metric
+	if len(b) < 8 {
+		return nil, fmt.Errorf("invalid input %s", tools.MessageHex(b))
+	}
 	p := 0
 	loc := LocatorTLV{}
 	f, err := UnmarshalLocatorFlags(b[p : p+1])
@@ -46,23 +53,11 @@ func UnmarshalSRv6LocatorTLV(b []byte) (*LocatorTLV, error) {
 		return nil, err
 	}
 	loc.Flag = f
-	if p+1 > len(b) {
-		return nil, fmt.Errorf("invalid input %s", tools.MessageHex(b))
-	}
 	p++
 	loc.Algorithm = b[p]
-	if p+1 > len(b) {
-		return nil, fmt.Errorf("invalid input %s", tools.MessageHex(b))
-	}
 	p++
-	// Skip reserved byte
-	if p+2 > len(b) {
-		return nil, fmt.Errorf("invalid input %s", tools.MessageHex(b))
-	}
+	// Skip reserved bytes
 	p += 2
-	if p+4 > len(b) {
-		return nil, fmt.Errorf("invalid input %s", tools.MessageHex(b))
-	}
 	loc.Metric = binary.BigEndian.Uint32(b[p : p+4])
 	p += 4
 
@@ -0,0 +1,55 @@
+package srv6
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestUnmarshalSRv6LocatorTLV(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *LocatorTLV
+		fail   bool
+	}{
+		{
+			name:  "flex algo locator",
+			input: []byte{0x80, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x0a},
+			expect: &LocatorTLV{
+				Flag:      &LocatorFlags{DFlag: true},
+				Algorithm: 128,
+				Metric:    10,
+			},
+		},
+		{
+			name:  "truncated locator",
+			input: []byte{0x00, 0x00, 0x00, 0x00},
+			fail:  true,
+		},
+		{
+			name:  "empty locator",
+			input: []byte{},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalSRv6LocatorTLV(tt.input)
+			if err != nil && !tt.fail {
+				t.Fatalf("supposed to succeed but failed with error: %+v", err)
+			}
+			if err == nil && tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected locator does not match the actual")
+			}
+		})
+	}
+}
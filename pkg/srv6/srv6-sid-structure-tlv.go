@@ -2,6 +2,7 @@ package srv6
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/golang/glog"
 	"github.com/sbezverk/tools"
@@ -26,11 +27,20 @@ func (s *SIDStructure) GetLen() uint16 {
 	return s.Length
 }
 
+// GetSIDLength returns the number of bits of SID described by the structure, the sum of Locator Block,
+// Locator Node, Function and Argument lengths, it must not exceed 128 bits, RFC 9514 section 8.
+func (s *SIDStructure) GetSIDLength() int {
+	return int(s.LBLength) + int(s.LNLength) + int(s.FunLength) + int(s.ArgLength)
+}
+
 // UnmarshalSRv6SIDStructureTLV builds SRv6 SID Structure TLV object
 func UnmarshalSRv6SIDStructureTLV(b []byte) (*SIDStructure, error) {
 	if glog.V(6) {
 		glog.Infof("SRv6 SID Structure TLV Raw: %s", tools.MessageHex(b))
 	}
+	if len(b) != 4 {
+		return nil, fmt.Errorf("invalid length %d of SRv6 SID Structure TLV", len(b))
+	}
 	st := SIDStructure{}
 	p := 0
 	st.LBLength = b[p]
@@ -40,6 +50,9 @@ func UnmarshalSRv6SIDStructureTLV(b []byte) (*SIDStructure, error) {
 	st.FunLength = b[p]
 	p++
 	st.ArgLength = b[p]
+	if st.GetSIDLength() > 128 {
+		return nil, fmt.Errorf("invalid SRv6 SID Structure, total length %d exceeds 128 bits", st.GetSIDLength())
+	}
 
 	return &st, nil
 }
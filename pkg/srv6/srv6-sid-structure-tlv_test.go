@@ -0,0 +1,76 @@
+package srv6
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestUnmarshalSRv6SIDStructureTLV(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect SubTLV
+		length int
+		fail   bool
+	}{
+		{
+			name:  "usid f3216 structure",
+			input: []byte{0x04, 0xe4, 0x00, 0x04, 0x20, 0x10, 0x10, 0x00},
+			expect: &SIDStructure{
+				Type:      1252,
+				Length:    8,
+				LBLength:  32,
+				LNLength:  16,
+				FunLength: 16,
+				ArgLength: 0,
+			},
+			length: 64,
+		},
+		{
+			name:  "structure with argument",
+			input: []byte{0x04, 0xe4, 0x00, 0x04, 0x28, 0x18, 0x10, 0x08},
+			expect: &SIDStructure{
+				Type:      1252,
+				Length:    8,
+				LBLength:  40,
+				LNLength:  24,
+				FunLength: 16,
+				ArgLength: 8,
+			},
+			length: 88,
+		},
+		{
+			name:  "invalid length",
+			input: []byte{0x04, 0xe4, 0x00, 0x03, 0x20, 0x10, 0x10},
+			fail:  true,
+		},
+		{
+			name:  "structure exceeds 128 bits",
+			input: []byte{0x04, 0xe4, 0x00, 0x04, 0x40, 0x40, 0x10, 0x00},
+			fail:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalSRv6SubTLV(tt.input)
+			if err != nil {
+				if !tt.fail {
+					t.Fatalf("test failed with error: %+v", err)
+				}
+				return
+			}
+			if tt.fail {
+				t.Fatal("supposed to fail but succeeded")
+			}
+			if !reflect.DeepEqual(tt.expect, got) {
+				t.Logf("Differences: %+v", deep.Equal(tt.expect, got))
+				t.Fatal("the expected sid structure does not match the actual")
+			}
+			if l := got.(*SIDStructure).GetSIDLength(); l != tt.length {
+				t.Fatalf("expected sid length %d, got %d", tt.length, l)
+			}
+		})
+	}
+}